@@ -0,0 +1,199 @@
+package intmat
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// The benchmarks in this file cover the main kernels over a fixed set of shapes and densities so
+// runs can be compared over time, cmd/benchtable turns their output into comparison tables.
+// Sub-benchmarks are named kernel/rowsxcols_density.
+
+type benchShape struct {
+	rows, cols int
+	density    float64
+}
+
+func (s benchShape) String() string {
+	return fmt.Sprintf("%vx%v_%v", s.rows, s.cols, s.density)
+}
+
+var benchShapes = []benchShape{
+	{32, 32, 0.5},
+	{128, 128, 0.05},
+	{128, 128, 0.5},
+	{512, 512, 0.01},
+	{1024, 64, 0.1},
+}
+
+// benchStringCells limits String benchmarks to shapes small enough to print.
+const benchStringCells = 128 * 128
+
+func BenchmarkMatrixOps(b *testing.B) {
+	for _, shape := range benchShapes {
+		rng := rand.New(rand.NewSource(1))
+		x := randomSparse(rng, shape.rows, shape.cols, shape.density)
+		y := randomSparse(rng, shape.rows, shape.cols, shape.density)
+		square := randomSparse(rng, shape.cols, shape.cols, shape.density)
+		name := shape.String()
+
+		b.Run("Mul/"+name, func(b *testing.B) {
+			mat := NewMat(shape.rows, shape.cols)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				mat.Mul(x, square)
+			}
+		})
+		b.Run("Add/"+name, func(b *testing.B) {
+			mat := NewMat(shape.rows, shape.cols)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				mat.Add(x, y)
+			}
+		})
+		b.Run("XOr/"+name, func(b *testing.B) {
+			mat := NewMat(shape.rows, shape.cols)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				mat.XOr(x, y)
+			}
+		})
+		b.Run("Pow/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				square.Pow(4)
+			}
+		})
+		b.Run("Copy/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				Copy(x)
+			}
+		})
+		b.Run("Equals/"+name, func(b *testing.B) {
+			other := Copy(x)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				x.Equals(other)
+			}
+		})
+		if shape.rows*shape.cols <= benchStringCells {
+			b.Run("String/"+name, func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					_ = x.String()
+				}
+			})
+		}
+		b.Run("MarshalJSON/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := json.Marshal(x); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run("UnmarshalJSON/"+name, func(b *testing.B) {
+			bs, err := json.Marshal(x)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				var m Matrix
+				if err := json.Unmarshal(bs, &m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBigIntMatrixOps(b *testing.B) {
+	for _, shape := range benchShapes {
+		rng := rand.New(rand.NewSource(1))
+		x := randomBigIntSparse(rng, shape.rows, shape.cols, shape.density)
+		y := randomBigIntSparse(rng, shape.rows, shape.cols, shape.density)
+		square := randomBigIntSparse(rng, shape.cols, shape.cols, shape.density)
+		name := shape.String()
+
+		b.Run("Mul/"+name, func(b *testing.B) {
+			mat := NewBigIntMat(shape.rows, shape.cols)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				mat.Mul(x, square)
+			}
+		})
+		b.Run("Add/"+name, func(b *testing.B) {
+			mat := NewBigIntMat(shape.rows, shape.cols)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				mat.Add(x, y)
+			}
+		})
+		b.Run("Pow/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				square.Pow(4)
+			}
+		})
+		b.Run("Copy/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				BigIntCopy(x)
+			}
+		})
+		b.Run("Equals/"+name, func(b *testing.B) {
+			other := BigIntCopy(x)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				x.Equals(other)
+			}
+		})
+		if shape.rows*shape.cols <= benchStringCells {
+			b.Run("String/"+name, func(b *testing.B) {
+				for n := 0; n < b.N; n++ {
+					_ = x.String()
+				}
+			})
+		}
+		b.Run("MarshalJSON/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := json.Marshal(x); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run("UnmarshalJSON/"+name, func(b *testing.B) {
+			bs, err := json.Marshal(x)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				var m BigIntMatrix
+				if err := json.Unmarshal(bs, &m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitMatrixOps(b *testing.B) {
+	for _, size := range []int{256, 1024} {
+		rng := rand.New(rand.NewSource(1))
+		x := NewBitMatFromMat(randomBits(rng, size, size, 0.5))
+		y := NewBitMatFromMat(randomBits(rng, size, size, 0.5))
+		name := benchShape{size, size, 0.5}.String()
+
+		b.Run("Mul/"+name, func(b *testing.B) {
+			mat := NewBitMat(size, size)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				mat.Mul(x, y)
+			}
+		})
+		b.Run("Echelonize/"+name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				BitCopy(x).Echelonize(true)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Dense multiplication settings for BigIntMatrix. Mul switches to the dense algorithm when every
+// dimension is at least denseMulMinSize and both inputs are at least denseMulMinDensity full.
+// The dense algorithm recurses with Strassen-Winograd while every dimension is at least
+// strassenThreshold and uses the blocked classical product below it.
+var (
+	denseMulMinSize    = 64
+	denseMulMinDensity = 0.25
+	strassenThreshold  = 64
+)
+
+// denseBlock is the block size of the classical dense product.
+const denseBlock = 32
+
+// MulDense multiplies two matrices and stores the values in this matrix like Mul, treating both
+// as dense. Large products use the Strassen-Winograd recursion (7 instead of 8 half size products)
+// and all temporaries come from one buffer of big.Int values that is reused across the recursion,
+// so the cost per product is mostly the arithmetic itself. It pays off once the inputs are mostly
+// nonzero, for example after Pow.
+func (mat *BigIntMatrix) MulDense(a, b *BigIntMatrix) {
+	if a == nil || b == nil {
+		panic("multiply input was found to be nil")
+	}
+
+	if mat == a || mat == b {
+		panic("multiply self assignment not allowed")
+	}
+
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("multiply shape misalignment can't multiply (%v,%v)x(%v,%v)", a.rows, a.cols, b.rows, b.cols))
+	}
+
+	if mat.rows != a.rows || mat.cols != b.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, b.cols))
+	}
+
+	mat.mulDense(a, b)
+}
+
+func (mat *BigIntMatrix) mulDense(a, b *BigIntMatrix) {
+	da, db := toDense(a), toDense(b)
+	dc := newDense(a.rows, b.cols)
+
+	var arena bigIntArena
+	strassen(dc, da, db, &arena)
+
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+	for i := 0; i < dc.rows; i++ {
+		for j := 0; j < dc.cols; j++ {
+			if v := dc.at(i, j); v.Sign() != 0 {
+				mat.set(i+mat.rowStart, j+mat.colStart, new(big.Int).Set(v))
+			}
+		}
+	}
+}
+
+// useDense reports whether the product of a and b is big and dense enough for mulDense.
+func useDense(a, b *BigIntMatrix) bool {
+	if a.rows < denseMulMinSize || a.cols < denseMulMinSize || b.cols < denseMulMinSize {
+		return false
+	}
+	return density(a) >= denseMulMinDensity && density(b) >= denseMulMinDensity
+}
+
+// density returns the fraction of nonzero values in the matrix.
+func density(m *BigIntMatrix) float64 {
+	count := 0
+	for _, r := range sortedBigIntKeys(m.rowValues(), m.rowStart, m.rows) {
+		count += len(m.rowEntries(r))
+	}
+	return float64(count) / float64(m.rows*m.cols)
+}
+
+// dense is a row major view into a slice of big.Int values.
+type dense struct {
+	data   []big.Int
+	offset int
+	stride int
+	rows   int
+	cols   int
+}
+
+func newDense(rows, cols int) dense {
+	return dense{data: make([]big.Int, rows*cols), stride: cols, rows: rows, cols: cols}
+}
+
+func toDense(m *BigIntMatrix) dense {
+	d := newDense(m.rows, m.cols)
+	m.DoNonzero(func(i, j int, value *big.Int) {
+		d.at(i, j).Set(value)
+	})
+	return d
+}
+
+func (d dense) at(i, j int) *big.Int {
+	return &d.data[d.offset+i*d.stride+j]
+}
+
+func (d dense) view(i, j, rows, cols int) dense {
+	return dense{data: d.data, offset: d.offset + i*d.stride + j, stride: d.stride, rows: rows, cols: cols}
+}
+
+// add stores x+y in d.
+func (d dense) add(x, y dense) {
+	for i := 0; i < d.rows; i++ {
+		for j := 0; j < d.cols; j++ {
+			d.at(i, j).Add(x.at(i, j), y.at(i, j))
+		}
+	}
+}
+
+// sub stores x-y in d.
+func (d dense) sub(x, y dense) {
+	for i := 0; i < d.rows; i++ {
+		for j := 0; j < d.cols; j++ {
+			d.at(i, j).Sub(x.at(i, j), y.at(i, j))
+		}
+	}
+}
+
+// mulAdd adds x*y to d with the classical product, blocked so the rows of y in use stay in cache.
+func (d dense) mulAdd(x, y dense, tmp *big.Int) {
+	for p0 := 0; p0 < x.cols; p0 += denseBlock {
+		p1 := p0 + denseBlock
+		if p1 > x.cols {
+			p1 = x.cols
+		}
+		for j0 := 0; j0 < y.cols; j0 += denseBlock {
+			j1 := j0 + denseBlock
+			if j1 > y.cols {
+				j1 = y.cols
+			}
+			for i := 0; i < x.rows; i++ {
+				for p := p0; p < p1; p++ {
+					xv := x.at(i, p)
+					if xv.Sign() == 0 {
+						continue
+					}
+					for j := j0; j < j1; j++ {
+						yv := y.at(p, j)
+						if yv.Sign() == 0 {
+							continue
+						}
+						d.at(i, j).Add(d.at(i, j), tmp.Mul(xv, yv))
+					}
+				}
+			}
+		}
+	}
+}
+
+// zero sets every value of d to zero keeping the buffers of the values.
+func (d dense) zero() {
+	for i := 0; i < d.rows; i++ {
+		for j := 0; j < d.cols; j++ {
+			d.at(i, j).SetInt64(0)
+		}
+	}
+}
+
+// bigIntArena hands out temporaries for the recursion in stack order. Released values keep their
+// buffers so later temporaries of the same size do not allocate.
+type bigIntArena struct {
+	values []big.Int
+	used   int
+	slab   int // counts the times values was replaced by a larger slice
+	tmp    big.Int
+}
+
+// alloc returns a rows x cols temporary, its values are not zeroed.
+func (arena *bigIntArena) alloc(rows, cols int) dense {
+	n := rows * cols
+	if arena.used+n > len(arena.values) {
+		// the temporaries already handed out keep the old values alive
+		arena.values = make([]big.Int, 2*(arena.used+n))
+		arena.used = 0
+		arena.slab++
+	}
+	d := dense{data: arena.values, offset: arena.used, stride: cols, rows: rows, cols: cols}
+	arena.used += n
+	return d
+}
+
+// mark returns the state to pass to release to free every temporary allocated after the mark.
+func (arena *bigIntArena) mark() (used, slab int) {
+	return arena.used, arena.slab
+}
+
+func (arena *bigIntArena) release(used, slab int) {
+	if slab == arena.slab {
+		arena.used = used
+		return
+	}
+	// everything in the current values was allocated after the mark
+	arena.used = 0
+}
+
+// strassen stores x*y in c using the Strassen-Winograd recursion. Odd dimensions are handled by
+// peeling the last row or column off and fixing it up with the classical product.
+func strassen(c, x, y dense, arena *bigIntArena) {
+	m, k, n := x.rows, x.cols, y.cols
+	if m < strassenThreshold || k < strassenThreshold || n < strassenThreshold || m < 2 || k < 2 || n < 2 {
+		c.zero()
+		c.mulAdd(x, y, &arena.tmp)
+		return
+	}
+
+	m2, k2, n2 := m&^1, k&^1, n&^1
+	strassenEven(c.view(0, 0, m2, n2), x.view(0, 0, m2, k2), y.view(0, 0, k2, n2), arena)
+	if k2 != k {
+		c.view(0, 0, m2, n2).mulAdd(x.view(0, k2, m2, 1), y.view(k2, 0, 1, n2), &arena.tmp)
+	}
+	if n2 != n {
+		last := c.view(0, n2, m2, 1)
+		last.zero()
+		last.mulAdd(x.view(0, 0, m2, k), y.view(0, n2, k, 1), &arena.tmp)
+	}
+	if m2 != m {
+		last := c.view(m2, 0, 1, n)
+		last.zero()
+		last.mulAdd(x.view(m2, 0, 1, k), y, &arena.tmp)
+	}
+}
+
+// strassenEven is one level of the Strassen-Winograd recursion for even dimensions.
+func strassenEven(c, x, y dense, arena *bigIntArena) {
+	m, k, n := x.rows/2, x.cols/2, y.cols/2
+	x11, x12, x21, x22 := x.view(0, 0, m, k), x.view(0, k, m, k), x.view(m, 0, m, k), x.view(m, k, m, k)
+	y11, y12, y21, y22 := y.view(0, 0, k, n), y.view(0, n, k, n), y.view(k, 0, k, n), y.view(k, n, k, n)
+	c11, c12, c21, c22 := c.view(0, 0, m, n), c.view(0, n, m, n), c.view(m, 0, m, n), c.view(m, n, m, n)
+
+	defer arena.release(arena.mark())
+
+	s := arena.alloc(m, k)
+	t := arena.alloc(k, n)
+	p := arena.alloc(m, n)
+
+	// c21 = S3*T3 = (x11-x21)(y22-y12)
+	s.sub(x11, x21)
+	t.sub(y22, y12)
+	strassen(c21, s, t, arena)
+
+	// c22 = S1*T1 = (x21+x22)(y12-y11)
+	s.add(x21, x22)
+	t.sub(y12, y11)
+	strassen(c22, s, t, arena)
+
+	// c12 = S2*T2 = (S1-x11)(y22-T1)
+	s.sub(s, x11)
+	t.sub(y22, t)
+	strassen(c12, s, t, arena)
+
+	// s = S4 = x12-S2
+	s.sub(x12, s)
+
+	// p = P1 = x11*y11, then c12 = U2 = P1+P6 and the other products follow
+	strassen(p, x11, y11, arena)
+	c12.add(c12, p)
+	c21.add(c21, c12) // U3 = U2+P7
+	c12.add(c12, c22) // U4 = U2+P5
+	c22.add(c22, c21) // U7 = U3+P5, C22 done
+
+	// c12 = U5 = U4+P3 with P3 = S4*y22
+	q := arena.alloc(m, n)
+	strassen(q, s, y22, arena)
+	c12.add(c12, q)
+
+	// c21 = U6 = U3-P4 with P4 = x22*T4 and T4 = T2-y21
+	t.sub(t, y21)
+	strassen(q, x22, t, arena)
+	c21.sub(c21, q)
+
+	// c11 = U1 = P1+P2 with P2 = x12*y21
+	strassen(c11, x12, y21, arena)
+	c11.add(c11, p)
+}
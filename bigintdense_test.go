@@ -0,0 +1,107 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestBigIntMatrix_MulDense(t *testing.T) {
+	defer func(threshold int) { strassenThreshold = threshold }(strassenThreshold)
+
+	rng := rand.New(rand.NewSource(4))
+	tests := []struct {
+		m, k, n   int
+		density   float64
+		threshold int
+	}{
+		{1, 1, 1, 1, 2},
+		{4, 4, 4, 1, 2},
+		{7, 9, 5, 0.8, 2},
+		{16, 16, 16, 1, 2},
+		{33, 17, 25, 0.5, 4},
+		{40, 40, 40, 1, 64},
+		{21, 30, 19, 0.1, 3},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%vx%vx%v_%v", test.m, test.k, test.n, test.threshold), func(t *testing.T) {
+			strassenThreshold = test.threshold
+			a := randomBigIntSparse(rng, test.m, test.k, test.density)
+			b := randomBigIntSparse(rng, test.k, test.n, test.density)
+
+			expected := NewBigIntMat(test.m, test.n)
+			bigIntMulInnerProduct(expected, a, b)
+			actual := NewBigIntMat(test.m, test.n)
+			actual.Set(0, 0, big.NewInt(99)) // stale values must be cleared
+			actual.MulDense(a, b)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestBigIntMatrix_MulDense_Views(t *testing.T) {
+	defer func(threshold int) { strassenThreshold = threshold }(strassenThreshold)
+	strassenThreshold = 2
+
+	rng := rand.New(rand.NewSource(8))
+	m := randomBigIntSparse(rng, 20, 20, 0.9)
+	a, b := m.Slice(1, 2, 11, 13), m.T().Slice(3, 4, 13, 9)
+
+	expected := NewBigIntMat(11, 9)
+	bigIntMulInnerProduct(expected, a, b)
+	out := NewBigIntMat(15, 15)
+	actual := out.Slice(2, 3, 11, 9)
+	actual.MulDense(a, b)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_Mul_Dense(t *testing.T) {
+	// Mul switches to the dense product for large dense inputs
+	rng := rand.New(rand.NewSource(6))
+	a := randomBigIntSparse(rng, 70, 65, 0.9)
+	b := randomBigIntSparse(rng, 65, 66, 0.9)
+	if !useDense(a, b) {
+		t.Fatalf("expected the dense product to be used")
+	}
+
+	expected := NewBigIntMat(70, 66)
+	bigIntMulInnerProduct(expected, a, b)
+	actual := NewBigIntMat(70, 66)
+	actual.Mul(a, b)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func BenchmarkBigIntMatrix_MulDense(b *testing.B) {
+	for _, size := range []int{64, 128, 256} {
+		rng := rand.New(rand.NewSource(1))
+		x := randomBigIntSparse(rng, size, size, 1)
+		y := randomBigIntSparse(rng, size, size, 1)
+
+		b.Run(fmt.Sprintf("dense_%v", size), func(b *testing.B) {
+			mat := NewBigIntMat(size, size)
+			for n := 0; n < b.N; n++ {
+				mat.MulDense(x, y)
+			}
+		})
+		b.Run(fmt.Sprintf("gustavson_%v", size), func(b *testing.B) {
+			mat := NewBigIntMat(size, size)
+			acc := newBigIntAccumulator(size)
+			for n := 0; n < b.N; n++ {
+				mat.zeroize(0, 0, size, size)
+				for r := 0; r < size; r++ {
+					acc.addRowProduct(x, y, r)
+					acc.flush(func(j int, value *big.Int) {
+						mat.set(r, j, value)
+					})
+				}
+			}
+		})
+	}
+}
@@ -4,19 +4,88 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/olekukonko/tablewriter"
 )
 
+// BigIntMatrix is a sparse matrix of *big.Int values.
+//
+// A value stored in a matrix is never modified afterwards, by the matrix or by anyone else, so
+// matrices, their copies and snapshots can share values freely. Set and NewBigIntMat copy the
+// values they are given, SetNoCopy stores the pointer itself and the caller must not modify the
+// value afterwards. Values handed out by At, DoNonzero and the like belong to the matrix and must
+// not be modified either, copy them first.
 type BigIntMatrix struct {
+	store      *bigIntMatStore // shared by this matrix and all of its views
+	transposed bool            // the rows of this matrix are the columns of store
+	rows       int             // total number rows available to this matrix
+	rowStart   int             // [rowStart,rowEnd)
+	cols       int             // total number cols available to this matrix
+	colStart   int             // [colStart,colEnd)
+
+}
+
+// bigIntMatStore holds the values of a matrix and all of its views. A Snapshot shares the maps of its
+// store until one of the stores sharing them is written to, that store then copies the maps first.
+type bigIntMatStore struct {
 	rowValues map[int]map[int]*big.Int //hold rowValues for (X,Y)
 	colValues map[int]map[int]*big.Int //easy access to (Y,X)
-	rows      int                      // total number rows available to this matrix
-	rowStart  int                      // [rowStart,rowEnd)
-	cols      int                      // total number cols available to this matrix
-	colStart  int                      // [colStart,colEnd)
+	shared    *int32                   // number of stores sharing the maps
+}
+
+func newBigIntMatStore() *bigIntMatStore {
+	return &bigIntMatStore{
+		rowValues: map[int]map[int]*big.Int{},
+		colValues: map[int]map[int]*big.Int{},
+		shared:    newShareCount(),
+	}
+}
+
+// own makes sure the store has maps of its own before they are written to.
+func (s *bigIntMatStore) own() {
+	if atomic.LoadInt32(s.shared) == 1 {
+		return
+	}
+
+	rowValues := make(map[int]map[int]*big.Int, len(s.rowValues))
+	colValues := make(map[int]map[int]*big.Int, len(s.colValues))
+	for r, cs := range s.rowValues {
+		for c, v := range cs {
+			if rowValues[r] == nil {
+				rowValues[r] = make(map[int]*big.Int, len(cs))
+			}
+			rowValues[r][c] = v
+			if colValues[c] == nil {
+				colValues[c] = make(map[int]*big.Int, len(s.colValues[c]))
+			}
+			colValues[c][r] = v
+		}
+	}
+	atomic.AddInt32(s.shared, -1)
+	s.rowValues, s.colValues, s.shared = rowValues, colValues, newShareCount()
+}
+
+func (mat *BigIntMatrix) rowValues() map[int]map[int]*big.Int {
+	if mat.store == nil {
+		return nil
+	}
+	if mat.transposed {
+		return mat.store.colValues
+	}
+	return mat.store.rowValues
+}
 
+func (mat *BigIntMatrix) colValues() map[int]map[int]*big.Int {
+	if mat.store == nil {
+		return nil
+	}
+	if mat.transposed {
+		return mat.store.rowValues
+	}
+	return mat.store.colValues
 }
 
 type bigintmatrix struct {
@@ -31,8 +100,8 @@ type bigintmatrix struct {
 
 func (mat *BigIntMatrix) MarshalJSON() ([]byte, error) {
 	return json.Marshal(bigintmatrix{
-		RowValues: mat.rowValues,
-		ColValues: mat.colValues,
+		RowValues: mat.rowValues(),
+		ColValues: mat.colValues(),
 		Rows:      mat.rows,
 		RowStart:  mat.rowStart,
 		Cols:      mat.cols,
@@ -46,8 +115,8 @@ func (mat *BigIntMatrix) UnmarshalJSON(bytes []byte) error {
 	if err != nil {
 		return err
 	}
-	mat.rowValues = m.RowValues
-	mat.colValues = m.ColValues
+	mat.store = &bigIntMatStore{rowValues: m.RowValues, colValues: m.ColValues, shared: newShareCount()}
+	mat.transposed = false
 	mat.rows = m.Rows
 	mat.rowStart = m.RowStart
 	mat.cols = m.Cols
@@ -59,19 +128,18 @@ func (mat *BigIntMatrix) UnmarshalJSON(bytes []byte) error {
 // If values is empty, the matrix will be zeroized.
 // If values are not empty it must have rows*cols items.  The values are expected to
 // be big.NewInt(0)/nil or big.NewInt(1) for logical operations, but can be any *big.Int for arithmetic.
-// Note value refs passed in will NOT be copied into new big.ints
+// The values are copied, the caller keeps ownership of them.
 func NewBigIntMat(rows, cols int, values ...*big.Int) *BigIntMatrix {
 	if len(values) != 0 && len(values) != rows*cols {
 		panic(fmt.Sprintf("matrix data length (%v) to size mismatch expected %v", len(values), rows*cols))
 	}
 
 	mat := BigIntMatrix{
-		rowValues: map[int]map[int]*big.Int{},
-		colValues: map[int]map[int]*big.Int{},
-		rows:      rows,
-		rowStart:  0,
-		cols:      cols,
-		colStart:  0,
+		store:    newBigIntMatStore(),
+		rows:     rows,
+		rowStart: 0,
+		cols:     cols,
+		colStart: 0,
 	}
 
 	if len(values) > 0 {
@@ -79,7 +147,7 @@ func NewBigIntMat(rows, cols int, values ...*big.Int) *BigIntMatrix {
 			for j := 0; j < cols; j++ {
 				index := i*cols + j
 				if values[index] != nil {
-					mat.set(i, j, values[index])
+					mat.set(i, j, new(big.Int).Set(values[index]))
 				}
 			}
 		}
@@ -95,12 +163,11 @@ func NewBigIntMatFromVec(vec *BigIntVector) *BigIntMatrix {
 // Identity create an identity matrix (one's on the diagonal).
 func BigIntIdentity(size int) *BigIntMatrix {
 	mat := BigIntMatrix{
-		rowValues: map[int]map[int]*big.Int{},
-		colValues: map[int]map[int]*big.Int{},
-		rows:      size,
-		rowStart:  0,
-		cols:      size,
-		colStart:  0,
+		store:    newBigIntMatStore(),
+		rows:     size,
+		rowStart: 0,
+		cols:     size,
+		colStart: 0,
 	}
 
 	for i := 0; i < size; i++ {
@@ -113,12 +180,11 @@ func BigIntIdentity(size int) *BigIntMatrix {
 // Copy will create a NEW matrix that will have all the same values as m.
 func BigIntCopy(m *BigIntMatrix) *BigIntMatrix {
 	mat := BigIntMatrix{
-		rowValues: make(map[int]map[int]*big.Int),
-		colValues: make(map[int]map[int]*big.Int),
-		rows:      m.rows,
-		rowStart:  0,
-		cols:      m.cols,
-		colStart:  0,
+		store:    newBigIntMatStore(),
+		rows:     m.rows,
+		rowStart: 0,
+		cols:     m.cols,
+		colStart: 0,
 	}
 
 	for i := 0; i < mat.rows; i++ {
@@ -130,6 +196,94 @@ func BigIntCopy(m *BigIntMatrix) *BigIntMatrix {
 	return &mat
 }
 
+// BigIntKron returns the Kronecker product of a and b, the block matrix made of b scaled by each value of a.
+func BigIntKron(a, b *BigIntMatrix) *BigIntMatrix {
+	if a == nil || b == nil {
+		panic("kronecker product input was found to be nil")
+	}
+
+	mat := NewBigIntMat(a.rows*b.rows, a.cols*b.cols)
+	a.DoNonzero(func(i, j int, v1 *big.Int) {
+		b.DoNonzero(func(k, l int, v2 *big.Int) {
+			mat.set(i*b.rows+k, j*b.cols+l, new(big.Int).Mul(v1, v2))
+		})
+	})
+
+	return mat
+}
+
+// BigIntDirectSum returns the block diagonal matrix with ms placed along the diagonal.
+func BigIntDirectSum(ms ...*BigIntMatrix) *BigIntMatrix {
+	rows, cols := 0, 0
+	for _, m := range ms {
+		if m == nil {
+			panic("direct sum input was found to be nil")
+		}
+		rows += m.rows
+		cols += m.cols
+	}
+
+	mat := NewBigIntMat(rows, cols)
+	i, j := 0, 0
+	for _, m := range ms {
+		mat.setMatrix(m, i, j)
+		i += m.rows
+		j += m.cols
+	}
+
+	return mat
+}
+
+// BigIntHStack returns the matrices placed side by side, they must all have the same number of rows.
+func BigIntHStack(ms ...*BigIntMatrix) *BigIntMatrix {
+	return BigIntBlock([][]*BigIntMatrix{ms})
+}
+
+// BigIntVStack returns the matrices placed one above the other, they must all have the same number of columns.
+func BigIntVStack(ms ...*BigIntMatrix) *BigIntMatrix {
+	blocks := make([][]*BigIntMatrix, len(ms))
+	for i, m := range ms {
+		blocks[i] = []*BigIntMatrix{m}
+	}
+	return BigIntBlock(blocks)
+}
+
+// BigIntBlock assembles a matrix from a grid of blocks. Blocks in the same block row must have the same
+// number of rows and blocks in the same block column the same number of columns. A nil block is a
+// zero block whose shape is taken from the other blocks in its block row and block column.
+func BigIntBlock(blocks [][]*BigIntMatrix) *BigIntMatrix {
+	heights, widths := blockShape(len(blocks), func(bi int) int { return len(blocks[bi]) }, func(bi, bj int) (int, int, bool) {
+		m := blocks[bi][bj]
+		if m == nil {
+			return 0, 0, false
+		}
+		return m.rows, m.cols, true
+	})
+
+	rows, cols := 0, 0
+	for _, h := range heights {
+		rows += h
+	}
+	for _, w := range widths {
+		cols += w
+	}
+
+	mat := NewBigIntMat(rows, cols)
+	i := 0
+	for bi, blockRow := range blocks {
+		j := 0
+		for bj, m := range blockRow {
+			if m != nil {
+				mat.setMatrix(m, i, j)
+			}
+			j += widths[bj]
+		}
+		i += heights[bi]
+	}
+
+	return mat
+}
+
 // Slice creates a slice of the matrix.  The slice will be connected to the original matrix, changes to one
 // causes changes in the other.
 func (mat *BigIntMatrix) Slice(i, j, rows, cols int) *BigIntMatrix {
@@ -153,12 +307,12 @@ func (mat *BigIntMatrix) Slice(i, j, rows, cols int) *BigIntMatrix {
 
 func (mat *BigIntMatrix) slice(r, c, rows, cols int) *BigIntMatrix {
 	return &BigIntMatrix{
-		rowValues: mat.rowValues,
-		rows:      rows,
-		rowStart:  r,
-		colValues: mat.colValues,
-		cols:      cols,
-		colStart:  c,
+		store:      mat.store,
+		transposed: mat.transposed,
+		rows:       rows,
+		rowStart:   r,
+		cols:       cols,
+		colStart:   c,
 	}
 }
 
@@ -194,7 +348,7 @@ func (mat *BigIntMatrix) At(i, j int) *big.Int {
 }
 
 func (mat *BigIntMatrix) at(r, c int) *big.Int {
-	ys, ok := mat.rowValues[r]
+	ys, ok := mat.rowValues()[r]
 	if !ok {
 		return nil
 	}
@@ -205,8 +359,17 @@ func (mat *BigIntMatrix) at(r, c int) *big.Int {
 	return v
 }
 
-// Set sets the value at row index i and column index j to value.
+// Set sets the value at row index i and column index j to a copy of value.
 func (mat *BigIntMatrix) Set(i, j int, value *big.Int) {
+	if value != nil && value.Sign() != 0 {
+		value = new(big.Int).Set(value)
+	}
+	mat.SetNoCopy(i, j, value)
+}
+
+// SetNoCopy sets the value at row index i and column index j to value without copying it. The
+// matrix takes ownership of value, the caller must not modify it afterwards.
+func (mat *BigIntMatrix) SetNoCopy(i, j int, value *big.Int) {
 	mat.checkRowBounds(i)
 	mat.checkColBounds(j)
 	r := i + mat.rowStart
@@ -219,7 +382,7 @@ func (mat *BigIntMatrix) set(r, c int, value *big.Int) {
 
 	// Treat nil as zero for convenience
 	if value == nil || value.Sign() == 0 {
-		ys, ok := mat.rowValues[r]
+		ys, ok := mat.rowValues()[r]
 		if !ok {
 			return
 		}
@@ -229,30 +392,33 @@ func (mat *BigIntMatrix) set(r, c int, value *big.Int) {
 			return
 		}
 
+		mat.store.own()
+		ys = mat.rowValues()[r]
 		delete(ys, c)
-		if len(mat.rowValues[r]) == 0 {
-			delete(mat.rowValues, r)
+		if len(mat.rowValues()[r]) == 0 {
+			delete(mat.rowValues(), r)
 		}
 
-		delete(mat.colValues[c], r)
-		if len(mat.colValues[c]) == 0 {
-			delete(mat.colValues, c)
+		delete(mat.colValues()[c], r)
+		if len(mat.colValues()[c]) == 0 {
+			delete(mat.colValues(), c)
 		}
 
 		return
 	}
 
-	ys, ok := mat.rowValues[r]
+	mat.store.own()
+	ys, ok := mat.rowValues()[r]
 	if !ok {
 		ys = make(map[int]*big.Int)
-		mat.rowValues[r] = ys
+		mat.rowValues()[r] = ys
 	}
 	ys[c] = value
 
-	xs, ok := mat.colValues[c]
+	xs, ok := mat.colValues()[c]
 	if !ok {
 		xs = make(map[int]*big.Int)
-		mat.colValues[c] = xs
+		mat.colValues()[c] = xs
 	}
 	xs[r] = value
 }
@@ -261,12 +427,37 @@ func (mat *BigIntMatrix) set(r, c int, value *big.Int) {
 // is connected to matrix it is a transpose of, and changes made to one affect the other.
 func (mat *BigIntMatrix) T() *BigIntMatrix {
 	return &BigIntMatrix{
-		rowValues: mat.colValues,
-		rows:      mat.cols,
-		rowStart:  mat.colStart,
-		colValues: mat.rowValues,
-		cols:      mat.rows,
-		colStart:  mat.rowStart,
+		store:      mat.store,
+		transposed: !mat.transposed,
+		rows:       mat.cols,
+		rowStart:   mat.colStart,
+		cols:       mat.rows,
+		colStart:   mat.rowStart,
+	}
+}
+
+// Snapshot returns a matrix with the same values as this one that shares its storage until
+// either side is written to, the first write then copies the storage. Unlike Slice and T the
+// snapshot does not see later changes to this matrix and this matrix does not see changes to the
+// snapshot. Views of a snapshot made with Slice or T share its storage as usual. Taking a
+// snapshot only reads this matrix, so several goroutines may snapshot it at the same time.
+func (mat *BigIntMatrix) Snapshot() *BigIntMatrix {
+	if mat.store == nil {
+		return &BigIntMatrix{store: newBigIntMatStore(), rows: mat.rows, cols: mat.cols}
+	}
+	atomic.AddInt32(mat.store.shared, 1)
+
+	return &BigIntMatrix{
+		store: &bigIntMatStore{
+			rowValues: mat.store.rowValues,
+			colValues: mat.store.colValues,
+			shared:    mat.store.shared,
+		},
+		transposed: mat.transposed,
+		rows:       mat.rows,
+		rowStart:   mat.rowStart,
+		cols:       mat.cols,
+		colStart:   mat.colStart,
 	}
 }
 
@@ -291,7 +482,7 @@ func (mat *BigIntMatrix) ZeroizeRange(i, j, rows, cols int) {
 }
 
 func (mat *BigIntMatrix) zeroize(r, c, rows, col int) {
-	for rv, cs := range mat.rowValues {
+	for rv, cs := range mat.rowValues() {
 		if rv < r || r+rows <= rv {
 			continue
 		}
@@ -307,29 +498,30 @@ func (mat *BigIntMatrix) zeroize(r, c, rows, col int) {
 // Pow raises the matrix to the power of k using exponentiation by squaring.
 // The matrix must be square.
 func (mat *BigIntMatrix) Pow(k int) *BigIntMatrix {
+	return mat.PowBig(big.NewInt(int64(k)))
+}
+
+// PowBig raises the matrix to the power of k using square-and-multiply, for exponents too large
+// for an int. The matrix must be square.
+func (mat *BigIntMatrix) PowBig(k *big.Int) *BigIntMatrix {
 	if mat.rows != mat.cols {
 		panic(fmt.Sprintf("matrix must be square to raise to a power, got %dx%d", mat.rows, mat.cols))
 	}
 
-	if k < 0 {
+	if k.Sign() < 0 {
 		panic("power k must be non-negative")
 	}
 
-	if k == 0 {
-		return BigIntIdentity(mat.rows)
-	}
-
 	result := BigIntIdentity(mat.rows)
-	currentPower := BigIntCopy(mat) // Use a copy to avoid modifying the original matrix if k=1
+	currentPower := mat // the original matrix is never written to
 
-	for k > 0 {
-		if k%2 == 1 {
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
 			temp := NewBigIntMat(mat.rows, mat.cols)
 			temp.Mul(result, currentPower)
 			result = temp
 		}
-		k /= 2
-		if k > 0 { // Avoid unnecessary multiplication if k becomes 0
+		if i+1 < k.BitLen() { // Avoid unnecessary multiplication after the last bit
 			temp := NewBigIntMat(mat.rows, mat.cols)
 			temp.Mul(currentPower, currentPower)
 			currentPower = temp
@@ -338,6 +530,52 @@ func (mat *BigIntMatrix) Pow(k int) *BigIntMatrix {
 	return result
 }
 
+// PowMod raises the matrix to the power of k modulo m using exponentiation by squaring, every
+// entry of the result is in [0,m). The matrix must be square, k non-negative and m positive.
+func (mat *BigIntMatrix) PowMod(k, m *big.Int) *BigIntMatrix {
+	if mat.rows != mat.cols {
+		panic(fmt.Sprintf("matrix must be square to raise to a power, got %dx%d", mat.rows, mat.cols))
+	}
+
+	if k.Sign() < 0 {
+		panic("power k must be non-negative")
+	}
+
+	if m.Sign() <= 0 {
+		panic(fmt.Sprintf("modulus m must be positive, got %v", m))
+	}
+
+	result := BigIntIdentity(mat.rows)
+	result.mod(m)
+	currentPower := BigIntCopy(mat)
+	currentPower.mod(m)
+
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			temp := NewBigIntMat(mat.rows, mat.cols)
+			temp.mul(result, currentPower)
+			temp.mod(m)
+			result = temp
+		}
+		if i+1 < k.BitLen() {
+			temp := NewBigIntMat(mat.rows, mat.cols)
+			temp.mul(currentPower, currentPower)
+			temp.mod(m)
+			currentPower = temp
+		}
+	}
+	return result
+}
+
+// mod reduces every entry of this matrix into [0,m).
+func (mat *BigIntMatrix) mod(m *big.Int) {
+	for _, r := range sortedBigIntKeys(mat.rowValues(), mat.rowStart, mat.rows) {
+		for c, v := range mat.rowEntries(r) {
+			mat.set(r, c, new(big.Int).Mod(v, m))
+		}
+	}
+}
+
 // Mul multiplies two matrices and stores the values in this matrix.
 func (mat *BigIntMatrix) Mul(a, b *BigIntMatrix) {
 	if a == nil || b == nil {
@@ -363,34 +601,122 @@ func (mat *BigIntMatrix) Mul(a, b *BigIntMatrix) {
 }
 
 func (mat *BigIntMatrix) mul(a, b *BigIntMatrix) {
+	if useDense(a, b) {
+		mat.mulDense(a, b)
+		return
+	}
+
 	//first we need to clear mat
 	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
 
-	for r, cs := range a.rowValues {
-		if r < a.rowStart || a.rowStart+a.rows <= r {
-			continue
+	acc := newBigIntAccumulator(b.cols)
+	for _, r := range sortedBigIntKeys(a.rowValues(), a.rowStart, a.rows) {
+		i := r - a.rowStart
+		acc.addRowProduct(a, b, r)
+		acc.flush(func(j int, value *big.Int) {
+			mat.set(i+mat.rowStart, j+mat.colStart, value)
+		})
+	}
+}
+
+// MulParallel multiplies two matrices and stores the values in this matrix like Mul, splitting
+// the rows of the result across workers goroutines. Each worker only reads a and b and collects
+// its rows locally, the rows are then written into this matrix from the calling goroutine.
+// A workers value less than 1 uses runtime.GOMAXPROCS(0).
+func (mat *BigIntMatrix) MulParallel(a, b *BigIntMatrix, workers int) {
+	if a == nil || b == nil {
+		panic("multiply input was found to be nil")
+	}
+
+	if mat == a || mat == b {
+		panic("multiply self assignment not allowed")
+	}
+
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("multiply shape misalignment can't multiply (%v,%v)x(%v,%v)", a.rows, a.cols, b.rows, b.cols))
+	}
+
+	if mat.rows != a.rows || mat.cols != b.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, b.cols))
+	}
+
+	aRows := sortedBigIntKeys(a.rowValues(), a.rowStart, a.rows)
+	type entry struct {
+		j     int
+		value *big.Int
+	}
+	results := make([][]entry, len(aRows))
+	parallelRows(len(aRows), workers, func(lo, hi int) {
+		acc := newBigIntAccumulator(b.cols)
+		for k := lo; k < hi; k++ {
+			acc.addRowProduct(a, b, aRows[k])
+			acc.flush(func(j int, value *big.Int) {
+				results[k] = append(results[k], entry{j, value})
+			})
 		}
+	})
+
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+	for k, r := range aRows {
 		i := r - a.rowStart
+		for _, e := range results[k] {
+			mat.set(i+mat.rowStart, e.j+mat.colStart, e.value)
+		}
+	}
+}
 
-		for c, rs := range b.colValues {
-			if c < b.colStart || b.colStart+b.cols <= c {
+// bigIntAccumulator is the sparse accumulator of Gustavson's algorithm, a dense row of values
+// plus the list of columns touched so far so emptying it costs only the number of those columns.
+// The values and the product scratch keep their buffers from row to row.
+type bigIntAccumulator struct {
+	values  []big.Int
+	touched []bool
+	cols    []int
+	prod    big.Int
+}
+
+func newBigIntAccumulator(cols int) *bigIntAccumulator {
+	return &bigIntAccumulator{
+		values:  make([]big.Int, cols),
+		touched: make([]bool, cols),
+	}
+}
+
+// addRowProduct adds row r (a stored row index) of a times b to the accumulator, that is the
+// rows of b scaled by the nonzero values of the row of a.
+func (acc *bigIntAccumulator) addRowProduct(a, b *BigIntMatrix, r int) {
+	for c, v1 := range a.rowValues()[r] {
+		if c < a.colStart || a.colStart+a.cols <= c {
+			continue
+		}
+
+		for cb, v2 := range b.rowValues()[c-a.colStart+b.rowStart] {
+			j := cb - b.colStart
+			if j < 0 || b.cols <= j {
 				continue
 			}
-			j := c - b.colStart
-			value := big.NewInt(0)
-			for ics, v1 := range cs {
-				ci := ics - a.colStart
-
-				v2, ok := rs[ci+b.rowStart]
-				if ok {
-					prod := new(big.Int).Mul(v1, v2)
-					value.Add(value, prod)
-				}
+
+			if !acc.touched[j] {
+				acc.touched[j] = true
+				acc.cols = append(acc.cols, j)
 			}
+			acc.values[j].Add(&acc.values[j], acc.prod.Mul(v1, v2))
+		}
+	}
+}
 
-			mat.Set(i, j, value)
+// flush calls fn with a copy of every nonzero accumulated value in increasing column order and
+// empties the accumulator.
+func (acc *bigIntAccumulator) flush(fn func(j int, value *big.Int)) {
+	sort.Ints(acc.cols)
+	for _, j := range acc.cols {
+		if acc.values[j].Sign() != 0 {
+			fn(j, new(big.Int).Set(&acc.values[j]))
 		}
+		acc.values[j].SetInt64(0)
+		acc.touched[j] = false
 	}
+	acc.cols = acc.cols[:0]
 }
 
 // Add stores the addition of a and b in this matrix.
@@ -416,19 +742,279 @@ func (mat *BigIntMatrix) add(a, b *BigIntMatrix) {
 	//first we need to clear mat
 	mat.setMatrix(a, mat.rowStart, mat.colStart)
 
-	for r, cs := range b.rowValues {
-		i := r - b.rowStart
-		mr := i + mat.rowStart
+	// values of b are shared where mat has no value and sums are built in a scratch value so a
+	// new value is only allocated for a nonzero sum
+	var sum big.Int
+	for r, cs := range b.rowValues() {
+		if r < b.rowStart || b.rowStart+b.rows <= r {
+			continue
+		}
+		mr := r - b.rowStart + mat.rowStart
 		for c, v := range cs {
-			j := c - b.colStart
-			mc := j + mat.colStart
+			if c < b.colStart || b.colStart+b.cols <= c {
+				continue
+			}
+			mc := c - b.colStart + mat.colStart
 			currentVal := mat.at(mr, mc)
 			if currentVal == nil {
-				mat.set(mr, mc, new(big.Int).Set(v))
+				mat.set(mr, mc, v)
+				continue
+			}
+
+			sum.Add(currentVal, v)
+			if sum.Sign() == 0 {
+				mat.set(mr, mc, nil)
 			} else {
-				mat.set(mr, mc, new(big.Int).Add(currentVal, v))
+				mat.set(mr, mc, new(big.Int).Set(&sum))
+			}
+		}
+	}
+}
+
+// Sub stores the subtraction a-b in this matrix.
+func (mat *BigIntMatrix) Sub(a, b *BigIntMatrix) {
+	mat.checkElementwise("subtraction", a, b)
+	if mat == a || mat == b {
+		panic("subtraction self assignment not allowed")
+	}
+
+	mat.sub(a, b)
+}
+
+func (mat *BigIntMatrix) sub(a, b *BigIntMatrix) {
+	mat.setMatrix(a, mat.rowStart, mat.colStart)
+
+	var diff big.Int
+	b.DoNonzero(func(i, j int, v *big.Int) {
+		r, c := i+mat.rowStart, j+mat.colStart
+		currentVal := mat.at(r, c)
+		if currentVal == nil {
+			mat.set(r, c, new(big.Int).Neg(v))
+			return
+		}
+
+		diff.Sub(currentVal, v)
+		if diff.Sign() == 0 {
+			mat.set(r, c, nil)
+		} else {
+			mat.set(r, c, new(big.Int).Set(&diff))
+		}
+	})
+}
+
+// MulElem stores the elementwise (Hadamard) product of a and b in this matrix.
+func (mat *BigIntMatrix) MulElem(a, b *BigIntMatrix) {
+	mat.checkElementwise("elementwise multiply", a, b)
+
+	mat.mulElem(a, b)
+}
+
+func (mat *BigIntMatrix) mulElem(a, b *BigIntMatrix) {
+	mat.applyPairs(a, b, func(x, y *big.Int) *big.Int {
+		if y == nil {
+			return nil
+		}
+		return new(big.Int).Mul(x, y)
+	})
+}
+
+// Rounding selects how Div rounds a quotient that is not an integer.
+type Rounding int
+
+const (
+	// RoundTowardZero truncates the quotient, like big.Int.Quo.
+	RoundTowardZero Rounding = iota
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil
+	// RoundEuclidean rounds so the remainder is non-negative, like big.Int.Div.
+	RoundEuclidean
+	// RoundHalfAwayFromZero rounds to the nearest integer with ties away from zero.
+	RoundHalfAwayFromZero
+	// RoundHalfEven rounds to the nearest integer with ties to the even integer.
+	RoundHalfEven
+)
+
+// Div stores the elementwise quotient a/b in this matrix rounded as given by mode. Every value of b
+// must be nonzero.
+func (mat *BigIntMatrix) Div(a, b *BigIntMatrix, mode Rounding) {
+	mat.checkElementwise("division", a, b)
+	if mode < RoundTowardZero || RoundHalfEven < mode {
+		panic(fmt.Sprintf("unknown rounding mode %v", mode))
+	}
+
+	mat.div(a, b, mode)
+}
+
+// Quo stores the elementwise quotient a/b truncated toward zero in this matrix. Every value of b
+// must be nonzero.
+func (mat *BigIntMatrix) Quo(a, b *BigIntMatrix) {
+	mat.Div(a, b, RoundTowardZero)
+}
+
+func (mat *BigIntMatrix) div(a, b *BigIntMatrix, mode Rounding) {
+	count := 0
+	for _, r := range sortedBigIntKeys(b.rowValues(), b.rowStart, b.rows) {
+		count += len(b.rowEntries(r))
+	}
+	if count != b.rows*b.cols {
+		panic("division by zero")
+	}
+
+	mat.applyPairs(a, b, func(x, y *big.Int) *big.Int {
+		return divRound(x, y, mode)
+	})
+}
+
+// divRound returns x/y rounded as given by mode.
+func divRound(x, y *big.Int, mode Rounding) *big.Int {
+	if mode == RoundEuclidean {
+		return new(big.Int).Div(x, y)
+	}
+
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// the exact quotient lies between q and q+away
+	away := int64(x.Sign() * y.Sign())
+	switch mode {
+	case RoundFloor:
+		if away < 0 {
+			q.Sub(q, big.NewInt(1))
+		}
+	case RoundCeil:
+		if away > 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	case RoundHalfAwayFromZero, RoundHalfEven:
+		half := r.Abs(r).Lsh(r, 1).CmpAbs(y)
+		if half > 0 || half == 0 && (mode == RoundHalfAwayFromZero || q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(away))
+		}
+	}
+	return q
+}
+
+// Scale stores a with every value multiplied by s in this matrix.
+func (mat *BigIntMatrix) Scale(a *BigIntMatrix, s *big.Int) {
+	mat.checkElementwise("scale", a, a)
+	if s == nil {
+		panic("scale factor was found to be nil")
+	}
+
+	if s.Sign() == 0 {
+		mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+		return
+	}
+	mat.apply(a, func(v *big.Int) *big.Int {
+		return new(big.Int).Mul(v, s)
+	})
+}
+
+// AddScalar stores a with s added to every value, zero values included, in this matrix.
+func (mat *BigIntMatrix) AddScalar(a *BigIntMatrix, s *big.Int) {
+	mat.checkElementwise("scalar addition", a, a)
+	if s == nil {
+		panic("scalar addition value was found to be nil")
+	}
+
+	if s.Sign() == 0 {
+		if mat != a {
+			mat.setMatrix(a, mat.rowStart, mat.colStart)
+		}
+		return
+	}
+
+	// zero values of a all share one copy of s
+	shifted := new(big.Int).Set(s)
+	values := make([]*big.Int, 0, a.rows*a.cols)
+	for i := 0; i < a.rows; i++ {
+		for j := 0; j < a.cols; j++ {
+			v := shifted
+			if x := a.at(i+a.rowStart, j+a.colStart); x != nil {
+				v = new(big.Int).Add(x, s)
 			}
+			values = append(values, v)
+		}
+	}
+
+	for k, v := range values {
+		if v.Sign() == 0 {
+			v = nil
+		}
+		mat.set(k/a.cols+mat.rowStart, k%a.cols+mat.colStart, v)
+	}
+}
+
+// Mod stores a with every value reduced into [0,m) in this matrix. The modulus m must be positive.
+func (mat *BigIntMatrix) Mod(a *BigIntMatrix, m *big.Int) {
+	mat.checkElementwise("modulus", a, a)
+	if m == nil {
+		panic("modulus was found to be nil")
+	}
+	if m.Sign() <= 0 {
+		panic(fmt.Sprintf("modulus must be positive, got %v", m))
+	}
+
+	mat.apply(a, func(v *big.Int) *big.Int {
+		return new(big.Int).Mod(v, m)
+	})
+}
+
+// Abs stores the absolute values of a in this matrix.
+func (mat *BigIntMatrix) Abs(a *BigIntMatrix) {
+	mat.checkElementwise("absolute value", a, a)
+
+	mat.apply(a, func(v *big.Int) *big.Int {
+		if v.Sign() > 0 {
+			return v
+		}
+		return new(big.Int).Neg(v)
+	})
+}
+
+// checkElementwise panics unless a, b and this matrix have the same shape.
+func (mat *BigIntMatrix) checkElementwise(op string, a, b *BigIntMatrix) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+
+	if a.rows != b.rows || a.cols != b.cols {
+		panic(fmt.Sprintf("%v input mat shapes do not match a=(%v,%v) b=(%v,%v)", op, a.rows, a.cols, b.rows, b.cols))
+	}
+	if mat.rows != a.rows || mat.cols != a.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, a.cols))
+	}
+}
+
+// apply stores fn of every nonzero value of a in this matrix, zero values of a stay zero. The
+// results are collected before this matrix is written so a may share values with it.
+func (mat *BigIntMatrix) apply(a *BigIntMatrix, fn func(v *big.Int) *big.Int) {
+	mat.applyPairs(a, a, func(x, _ *big.Int) *big.Int {
+		return fn(x)
+	})
+}
+
+// applyPairs stores fn(x,y) in this matrix for every nonzero value x of a and the value y of b at
+// the same position, nil where b is zero. Positions where a is zero become zero.
+func (mat *BigIntMatrix) applyPairs(a, b *BigIntMatrix, fn func(x, y *big.Int) *big.Int) {
+	type entry struct {
+		i, j  int
+		value *big.Int
+	}
+	var entries []entry
+	a.DoNonzero(func(i, j int, x *big.Int) {
+		if v := fn(x, b.at(i+b.rowStart, j+b.colStart)); v != nil && v.Sign() != 0 {
+			entries = append(entries, entry{i, j, v})
 		}
+	})
+
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+	for _, e := range entries {
+		mat.set(e.i+mat.rowStart, e.j+mat.colStart, e.value)
 	}
 }
 
@@ -452,13 +1038,13 @@ func (mat *BigIntMatrix) SetColumn(j int, vec *TransposedBigIntVector) {
 	c := j + mat.colStart
 
 	//first we'll zeroize
-	rs := mat.colValues[c]
+	rs := mat.colValues()[c]
 	for r := range rs {
 		mat.set(r, c, nil)
 	}
 
 	//now set the new values
-	for i, v := range vec.mat.colValues[vec.mat.colStart] {
+	for i, v := range vec.mat.colValues()[vec.mat.colStart] {
 		r := i + mat.rowStart
 		mat.set(r, c, v)
 	}
@@ -484,18 +1070,137 @@ func (mat *BigIntMatrix) SetRow(i int, vec *BigIntVector) {
 	r := i + mat.rowStart
 
 	//first we'll zeroize
-	cs := mat.rowValues[r]
+	cs := mat.rowValues()[r]
 	for c := range cs {
 		mat.set(r, c, nil)
 	}
 
 	//now set the new values
-	for j, v := range vec.mat.rowValues[vec.mat.rowStart] {
+	for j, v := range vec.mat.rowValues()[vec.mat.rowStart] {
 		c := j + mat.colStart
 		mat.set(r, c, v)
 	}
 }
 
+// SwapRows swaps rows i and k. Only the values in the two rows are touched.
+func (mat *BigIntMatrix) SwapRows(i, k int) {
+	mat.checkRowBounds(i)
+	mat.checkRowBounds(k)
+	if i == k {
+		return
+	}
+
+	r1 := i + mat.rowStart
+	r2 := k + mat.rowStart
+	cs1 := mat.rowEntries(r1)
+	cs2 := mat.rowEntries(r2)
+	for c := range cs1 {
+		mat.set(r1, c, nil)
+	}
+	for c := range cs2 {
+		mat.set(r2, c, nil)
+	}
+	for c, v := range cs1 {
+		mat.set(r2, c, v)
+	}
+	for c, v := range cs2 {
+		mat.set(r1, c, v)
+	}
+}
+
+// SwapCols swaps columns j and k. Only the values in the two columns are touched.
+func (mat *BigIntMatrix) SwapCols(j, k int) {
+	mat.T().SwapRows(j, k)
+}
+
+// PermuteRows moves row i to row perm[i] for every row. perm must be a permutation of [0,rows).
+func (mat *BigIntMatrix) PermuteRows(perm []int) {
+	checkPermutation(perm, mat.rows)
+
+	rows := make([]map[int]*big.Int, mat.rows)
+	for i := range rows {
+		r := i + mat.rowStart
+		rows[i] = mat.rowEntries(r)
+		for c := range rows[i] {
+			mat.set(r, c, nil)
+		}
+	}
+	for i, cs := range rows {
+		r := perm[i] + mat.rowStart
+		for c, v := range cs {
+			mat.set(r, c, v)
+		}
+	}
+}
+
+// PermuteCols moves column j to column perm[j] for every column. perm must be a permutation of [0,cols).
+func (mat *BigIntMatrix) PermuteCols(perm []int) {
+	mat.T().PermuteRows(perm)
+}
+
+// RemoveRows returns a NEW matrix holding the rows of this matrix that are not listed in rows.
+func (mat *BigIntMatrix) RemoveRows(rows ...int) *BigIntMatrix {
+	removed := make(map[int]bool, len(rows))
+	for _, i := range rows {
+		mat.checkRowBounds(i)
+		removed[i] = true
+	}
+
+	result := NewBigIntMat(mat.rows-len(removed), mat.cols)
+	ri := 0
+	for i := 0; i < mat.rows; i++ {
+		if removed[i] {
+			continue
+		}
+		for c, v := range mat.rowEntries(i + mat.rowStart) {
+			result.set(ri, c-mat.colStart, v)
+		}
+		ri++
+	}
+
+	return result
+}
+
+// RemoveCols returns a NEW matrix holding the columns of this matrix that are not listed in cols.
+func (mat *BigIntMatrix) RemoveCols(cols ...int) *BigIntMatrix {
+	return mat.T().RemoveRows(cols...).T()
+}
+
+// InsertZeroRows returns a NEW matrix with count rows of zeros inserted before row i of this matrix.
+// An i equal to the number of rows appends the zero rows at the bottom.
+func (mat *BigIntMatrix) InsertZeroRows(i, count int) *BigIntMatrix {
+	if i < 0 || i > mat.rows {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, mat.rows))
+	}
+	if count < 0 {
+		panic("count must be non-negative")
+	}
+
+	result := NewBigIntMat(mat.rows+count, mat.cols)
+	result.setMatrix(mat.slice(mat.rowStart, mat.colStart, i, mat.cols), 0, 0)
+	result.setMatrix(mat.slice(mat.rowStart+i, mat.colStart, mat.rows-i, mat.cols), i+count, 0)
+
+	return result
+}
+
+// InsertZeroCols returns a NEW matrix with count columns of zeros inserted before column j of this matrix.
+// A j equal to the number of columns appends the zero columns on the right.
+func (mat *BigIntMatrix) InsertZeroCols(j, count int) *BigIntMatrix {
+	return mat.T().InsertZeroRows(j, count).T()
+}
+
+// rowEntries returns the values of row r that are inside this matrix's columns, keyed by column.
+func (mat *BigIntMatrix) rowEntries(r int) map[int]*big.Int {
+	entries := make(map[int]*big.Int)
+	for c, v := range mat.rowValues()[r] {
+		if c < mat.colStart || mat.colStart+mat.cols <= c {
+			continue
+		}
+		entries[c] = v
+	}
+	return entries
+}
+
 // Equals return true if the m matrix has the same shape and values as this matrix.
 func (mat *BigIntMatrix) Equals(m *BigIntMatrix) bool {
 	if mat == m {
@@ -512,9 +1217,9 @@ func (mat *BigIntMatrix) Equals(m *BigIntMatrix) bool {
 
 	for i := 0; i < mat.rows; i++ {
 		r := i + mat.rowStart
-		cs, ok1 := mat.rowValues[r]
+		cs, ok1 := mat.rowValues()[r]
 		ar := i + m.rowStart
-		acs, ok2 := m.rowValues[ar]
+		acs, ok2 := m.rowValues()[ar]
 
 		if !ok1 && !ok2 {
 			continue
@@ -567,6 +1272,17 @@ func (mat BigIntMatrix) String() string {
 	return buff.String()
 }
 
+// DoNonzero calls fn for each nonzero value in the matrix. Values are visited in row-major order with
+// row and column indices increasing, so the order is the same from run to run.
+func (mat *BigIntMatrix) DoNonzero(fn func(i, j int, value *big.Int)) {
+	for _, r := range sortedBigIntKeys(mat.rowValues(), mat.rowStart, mat.rows) {
+		cs := mat.rowValues()[r]
+		for _, c := range sortedBigIntInnerKeys(cs, mat.colStart, mat.cols) {
+			fn(r-mat.rowStart, c-mat.colStart, cs[c])
+		}
+	}
+}
+
 // SetMatrix replaces the values of this matrix with the values of from matrix a. The shape of 'a' must be less than or equal mat.
 // If the 'a' shape is less then iOffset and jOffset can be used to place 'a' matrix in a specific location.
 func (mat *BigIntMatrix) SetMatrix(a *BigIntMatrix, iOffset, jOffset int) {
@@ -583,10 +1299,16 @@ func (mat *BigIntMatrix) SetMatrix(a *BigIntMatrix, iOffset, jOffset int) {
 func (mat *BigIntMatrix) setMatrix(a *BigIntMatrix, rOffset, cOffset int) {
 	mat.zeroize(rOffset, cOffset, a.rows, a.cols)
 
-	for r, cs := range a.rowValues {
+	for r, cs := range a.rowValues() {
+		if r < a.rowStart || a.rowStart+a.rows <= r {
+			continue
+		}
 		i := r - a.rowStart
 		mr := i + rOffset
 		for c, v := range cs {
+			if c < a.colStart || a.colStart+a.cols <= c {
+				continue
+			}
 			j := c - a.colStart
 			mc := j + cOffset
 			mat.set(mr, mc, v)
@@ -603,8 +1325,34 @@ func (mat *BigIntMatrix) Negate() {
 
 			currentVal := mat.at(r, c)
 			if currentVal != nil {
-				mat.set(r, c, currentVal.Neg(currentVal))
+				mat.set(r, c, new(big.Int).Neg(currentVal))
 			}
 		}
 	}
 }
+
+// sortedBigIntKeys returns the keys of values inside [start,start+length) in increasing order.
+func sortedBigIntKeys(values map[int]map[int]*big.Int, start, length int) []int {
+	keys := make([]int, 0, len(values))
+	for k := range values {
+		if k < start || start+length <= k {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// sortedBigIntInnerKeys returns the keys of values inside [start,start+length) in increasing order.
+func sortedBigIntInnerKeys(values map[int]*big.Int, start, length int) []int {
+	keys := make([]int, 0, len(values))
+	for k := range values {
+		if k < start || start+length <= k {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -2,8 +2,13 @@ package intmat
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -238,6 +243,53 @@ func TestBigIntMatrix_Mul(t *testing.T) {
 		})
 	}
 }
+
+func TestBigIntMatrix_Mul_Views(t *testing.T) {
+	// both inputs have values just outside their windows that must not be paired up
+	a := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6})...).Slice(0, 0, 2, 2)
+	b := NewBigIntMat(3, 2, intsToBigInts([]int{1, 0, 0, 1, 1, 1})...).Slice(0, 0, 2, 2)
+	actual := NewBigIntMat(2, 2)
+	actual.Mul(a, b)
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{1, 2, 4, 5})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+func TestBigIntMatrix_Mul_Deterministic(t *testing.T) {
+	a, b := NewBigIntMat(3, 8), NewBigIntMat(8, 20)
+	for i := 0; i < 3; i++ {
+		for k := 0; k < 8; k++ {
+			a.Set(i, k, big.NewInt(int64(i+k+1)))
+		}
+	}
+	for k := 0; k < 8; k++ {
+		for j := k % 2; j < 20; j += 2 {
+			b.Set(k, j, big.NewInt(int64(k-j)))
+		}
+	}
+
+	// the accumulator hands out each row in column order whatever order the maps are walked in
+	acc := newBigIntAccumulator(20)
+	for r := 0; r < 3; r++ {
+		acc.addRowProduct(a, b, r)
+		var cols []int
+		acc.flush(func(j int, _ *big.Int) {
+			cols = append(cols, j)
+		})
+		if !sort.IntsAreSorted(cols) {
+			t.Fatalf("expected row %v in column order but found %v", r, cols)
+		}
+	}
+
+	first, second, parallel := NewBigIntMat(3, 20), NewBigIntMat(3, 20), NewBigIntMat(3, 20)
+	first.Mul(a, b)
+	second.Mul(a, b)
+	parallel.MulParallel(a, b, 2)
+	if first.String() != second.String() || first.String() != parallel.String() {
+		t.Fatalf("expected identical results but found:\n%v\n%v\n%v", first, second, parallel)
+	}
+}
+
 func TestBigIntMatrix_Zeroize(t *testing.T) {
 	tests := []struct {
 		original *BigIntMatrix
@@ -413,6 +465,78 @@ func TestBigIntMatrix_SetRow(t *testing.T) {
 		})
 	}
 }
+
+func TestBigIntMatrix_SwapRows(t *testing.T) {
+	m := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})...)
+	m.Slice(0, 1, 3, 2).SwapRows(0, 2)
+
+	expected := NewBigIntMat(3, 3, intsToBigInts([]int{1, 8, 9, 4, 5, 6, 7, 2, 3})...)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestBigIntMatrix_SwapCols(t *testing.T) {
+	m := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6})...)
+	m.SwapCols(0, 2)
+
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{3, 2, 1, 6, 5, 4})...)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestBigIntMatrix_PermuteRows(t *testing.T) {
+	m := NewBigIntMat(3, 1, intsToBigInts([]int{1, 2, 3})...)
+	m.PermuteRows([]int{1, 2, 0})
+
+	expected := NewBigIntMat(3, 1, intsToBigInts([]int{3, 1, 2})...)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestBigIntMatrix_PermuteCols(t *testing.T) {
+	m := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6})...)
+	m.PermuteCols([]int{2, 0, 1})
+
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{2, 3, 1, 5, 6, 4})...)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestBigIntMatrix_RemoveRows(t *testing.T) {
+	actual := NewBigIntMat(3, 2, intsToBigInts([]int{1, 2, 3, 4, 5, 6})...).RemoveRows(2, 0)
+	expected := NewBigIntMat(1, 2, intsToBigInts([]int{3, 4})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_RemoveCols(t *testing.T) {
+	actual := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6})...).RemoveCols(1)
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{1, 3, 4, 6})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_InsertZeroRows(t *testing.T) {
+	actual := NewBigIntMat(2, 2, intsToBigInts([]int{1, 2, 3, 4})...).InsertZeroRows(1, 2)
+	expected := NewBigIntMat(4, 2, intsToBigInts([]int{1, 2, 0, 0, 0, 0, 3, 4})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_InsertZeroCols(t *testing.T) {
+	actual := NewBigIntMat(2, 2, intsToBigInts([]int{1, 2, 3, 4})...).InsertZeroCols(2, 1)
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 0, 3, 4, 0})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
 func TestBigIntMatrix_SetMatrix(t *testing.T) {
 	tests := []struct {
 		dest             *BigIntMatrix
@@ -464,6 +588,89 @@ func TestBigIntMatrix_SetMatrix2(t *testing.T) {
 		})
 	}
 }
+
+func TestBigIntMatrix_SetMatrix3(t *testing.T) {
+	source := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})...)
+	m := NewBigIntMat(3, 3)
+	m.SetMatrix(source.Slice(1, 1, 2, 2), 0, 1)
+
+	expected := NewBigIntMat(3, 3, intsToBigInts([]int{0, 5, 6, 0, 8, 9, 0, 0, 0})...)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestBigIntKron(t *testing.T) {
+	tests := []struct {
+		a, b, expected *BigIntMatrix
+	}{
+		{BigIntIdentity(2), NewBigIntMat(1, 2, intsToBigInts([]int{1, 2})...), NewBigIntMat(2, 4, intsToBigInts([]int{1, 2, 0, 0, 0, 0, 1, 2})...)},
+		{NewBigIntMat(2, 2, intsToBigInts([]int{1, 1, 0, 1})...), NewBigIntMat(2, 1, intsToBigInts([]int{1, -1})...), NewBigIntMat(4, 2, intsToBigInts([]int{1, 1, -1, -1, 0, 1, 0, -1})...)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := BigIntKron(test.a, test.b)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBigIntDirectSum(t *testing.T) {
+	actual := BigIntDirectSum(NewBigIntMat(1, 2, intsToBigInts([]int{1, 2})...), NewBigIntMat(2, 1, intsToBigInts([]int{3, 4})...))
+	expected := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 0, 0, 0, 3, 0, 0, 4})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBigIntHStack(t *testing.T) {
+	actual := BigIntHStack(BigIntIdentity(2), NewBigIntMat(2, 1, intsToBigInts([]int{3, 4})...))
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{1, 0, 3, 0, 1, 4})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBigIntVStack(t *testing.T) {
+	actual := BigIntVStack(BigIntIdentity(2), NewBigIntMat(1, 2, intsToBigInts([]int{3, 4})...))
+	expected := NewBigIntMat(3, 2, intsToBigInts([]int{1, 0, 0, 1, 3, 4})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBigIntBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   [][]*BigIntMatrix
+		expected *BigIntMatrix
+		panic    bool
+	}{
+		{"zero_blocks", [][]*BigIntMatrix{{BigIntIdentity(2), nil}, {nil, NewBigIntMat(1, 1, big.NewInt(5))}}, NewBigIntMat(3, 3, intsToBigInts([]int{1, 0, 0, 0, 1, 0, 0, 0, 5})...), false},
+		{"height_mismatch", [][]*BigIntMatrix{{BigIntIdentity(2), BigIntIdentity(1)}}, nil, true},
+		{"all_nil_column", [][]*BigIntMatrix{{BigIntIdentity(2), nil}}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if test.panic && r == nil {
+					t.Errorf("expected panic but did not get one")
+				}
+				if !test.panic && r != nil {
+					t.Errorf("did not expect panic but got: %v", r)
+				}
+			}()
+
+			actual := BigIntBlock(test.blocks)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
 func TestBigIntMatrix_JSON(t *testing.T) {
 	m := BigIntIdentity(3)
 
@@ -481,6 +688,29 @@ func TestBigIntMatrix_JSON(t *testing.T) {
 		t.Fatalf("expected\n%v\nbut found\n%v", m, actual)
 	}
 }
+
+func TestBigIntMatrix_DoNonzero(t *testing.T) {
+	tests := []struct {
+		m        *BigIntMatrix
+		expected [][3]int
+	}{
+		{NewBigIntMat(2, 2), nil},
+		{NewBigIntMat(3, 3, intsToBigInts([]int{0, 1, 1, 1, 0, 0, 0, 0, 2})...), [][3]int{{0, 1, 1}, {0, 2, 1}, {1, 0, 1}, {2, 2, 2}}},
+		{NewBigIntMat(3, 3, intsToBigInts([]int{0, 1, 1, 1, 0, 0, 0, 0, 2})...).Slice(1, 1, 2, 2), [][3]int{{1, 1, 2}}},
+		{NewBigIntMat(2, 3, intsToBigInts([]int{1, 0, 3, 0, 2, 0})...).T(), [][3]int{{0, 0, 1}, {1, 1, 2}, {2, 0, 3}}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var actual [][3]int
+			test.m.DoNonzero(func(i, j int, value *big.Int) {
+				actual = append(actual, [3]int{i, j, int(value.Int64())})
+			})
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
 func TestBigIntMatrix_Negate(t *testing.T) {
 	tests := []struct {
 		x, expected *BigIntMatrix
@@ -549,3 +779,431 @@ func TestBigIntMatrix_Pow(t *testing.T) {
 		})
 	}
 }
+
+func TestBigIntMatrix_PowMod(t *testing.T) {
+	m := NewBigIntMat(3, 3, intsToBigInts([]int{0, 1, 1, 1, 0, 1, 1, 1, 0})...)
+	mod := big.NewInt(1000003)
+	for k := 0; k < 20; k++ {
+		expected := m.Pow(k)
+		expected.mod(mod)
+		actual := m.PowMod(big.NewInt(int64(k)), mod)
+		if !actual.Equals(expected) {
+			t.Fatalf("k=%v expected:\n%v\nbut found:\n%v", k, expected, actual)
+		}
+	}
+
+	// a 3-cycle has P^3 = I and 2^100 = 1 mod 3 so the power is P itself
+	cycle := NewBigIntMat(3, 3, intsToBigInts([]int{0, 1, 0, 0, 0, 1, 1, 0, 0})...)
+	actual := cycle.PowMod(new(big.Int).Lsh(big.NewInt(1), 100), big.NewInt(5))
+	expected := NewBigIntMat(3, 3, intsToBigInts([]int{0, 1, 0, 0, 0, 1, 1, 0, 0})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	actual = NewBigIntMat(1, 1, big.NewInt(-4)).PowMod(big.NewInt(1), big.NewInt(3))
+	expected = NewBigIntMat(1, 1, big.NewInt(2))
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_MulParallel(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	random := func(rows, cols int) *BigIntMatrix {
+		m := NewBigIntMat(rows, cols)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if rng.Intn(3) == 0 {
+					m.Set(i, j, new(big.Int).Lsh(big.NewInt(rng.Int63n(11)-5), 70))
+				}
+			}
+		}
+		return m
+	}
+
+	a := random(19, 13)
+	b := random(13, 21)
+	expected := NewBigIntMat(19, 21)
+	expected.Mul(a, b)
+
+	for _, workers := range []int{0, 1, 4, 64} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			actual := NewBigIntMat(19, 21)
+			actual.Set(0, 0, big.NewInt(99)) // stale values must be cleared
+			actual.MulParallel(a, b, workers)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+			}
+		})
+	}
+
+	views := random(30, 30)
+	as, bs := views.Slice(2, 3, 10, 12), views.Slice(5, 1, 12, 7)
+	expected = NewBigIntMat(10, 7)
+	expected.Mul(as, bs)
+	actual := NewBigIntMat(10, 7)
+	actual.MulParallel(as, bs, 3)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_Snapshot(t *testing.T) {
+	m := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6})...)
+	snapshot := m.Snapshot()
+	view := m.T()
+
+	m.Negate()
+	m.Set(0, 0, big.NewInt(10))
+	snapshot.Set(1, 2, nil)
+
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{10, -2, -3, -4, -5, -6})...)
+	if !m.Equals(expected) || !view.Equals(expected.T()) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+	expected = NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 0})...)
+	if !snapshot.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, snapshot)
+	}
+}
+
+// randomBigIntSparse returns a rows x cols matrix where each value is nonzero with probability
+// density.
+func randomBigIntSparse(rng *rand.Rand, rows, cols int, density float64) *BigIntMatrix {
+	m := NewBigIntMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if rng.Float64() < density {
+				m.Set(i, j, new(big.Int).Lsh(big.NewInt(rng.Int63n(9)-4), 64))
+			}
+		}
+	}
+	return m
+}
+
+// bigIntMulInnerProduct is the inner product multiply Mul used before Gustavson's algorithm, it is
+// kept to check and benchmark against.
+func bigIntMulInnerProduct(mat, a, b *BigIntMatrix) {
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+
+	bCols := sortedBigIntKeys(b.colValues(), b.colStart, b.cols)
+	for _, r := range sortedBigIntKeys(a.rowValues(), a.rowStart, a.rows) {
+		cs := a.rowValues()[r]
+		i := r - a.rowStart
+
+		for _, c := range bCols {
+			rs := b.colValues()[c]
+			j := c - b.colStart
+			value := big.NewInt(0)
+			for ics, v1 := range cs {
+				if ics < a.colStart || a.colStart+a.cols <= ics {
+					continue
+				}
+				ci := ics - a.colStart
+
+				v2, ok := rs[ci+b.rowStart]
+				if ok {
+					prod := new(big.Int).Mul(v1, v2)
+					value.Add(value, prod)
+				}
+			}
+
+			mat.Set(i, j, value)
+		}
+	}
+}
+
+func TestBigIntMatrix_Pow_KeepsStorage(t *testing.T) {
+	for _, m := range []*BigIntMatrix{NewBigIntMat(2, 2, intsToBigInts([]int{1, 2, 3, 4})...), NewBigIntMat(2, 2)} {
+		rowValues := reflect.ValueOf(m.store.rowValues).Pointer()
+		m.Pow(3)
+		m.PowMod(big.NewInt(3), big.NewInt(5))
+		m.Set(0, 1, big.NewInt(7))
+		if reflect.ValueOf(m.store.rowValues).Pointer() != rowValues {
+			t.Fatalf("expected the original to keep its storage")
+		}
+	}
+}
+
+func TestBigIntMatrix_Snapshot_Concurrent(t *testing.T) {
+	m := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})...)
+	expected := BigIntCopy(m)
+
+	snapshots := make([]*BigIntMatrix, 8)
+	var wg sync.WaitGroup
+	for k := range snapshots {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			snapshots[k] = m.Snapshot()
+		}(k)
+	}
+	wg.Wait()
+
+	m.Set(0, 0, big.NewInt(10))
+	for _, snapshot := range snapshots {
+		if !snapshot.Equals(expected) {
+			t.Fatalf("expected:\n%v\nbut found:\n%v", expected, snapshot)
+		}
+	}
+}
+
+func TestBigIntMatrix_Snapshot_ZeroValue(t *testing.T) {
+	var m BigIntMatrix
+	snapshot := m.Snapshot()
+	if rows, cols := snapshot.Dims(); rows != 0 || cols != 0 {
+		t.Fatalf("expected an empty matrix but found (%v,%v)", rows, cols)
+	}
+}
+
+func TestBigIntMatrix_Mul_InnerProduct(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, density := range []float64{0.05, 0.3, 1} {
+		m := randomBigIntSparse(rng, 40, 40, density)
+		a, b := m.Slice(0, 3, 25, 30), m.T().Slice(5, 2, 30, 20)
+
+		expected := NewBigIntMat(25, 20)
+		bigIntMulInnerProduct(expected, a, b)
+		actual := NewBigIntMat(25, 20)
+		actual.Mul(a, b)
+		if !actual.Equals(expected) {
+			t.Fatalf("density %v expected:\n%v\nbut found:\n%v", density, expected, actual)
+		}
+	}
+}
+
+func BenchmarkBigIntMatrix_Mul(b *testing.B) {
+	for _, size := range []int{100, 300} {
+		for _, density := range []float64{0.01, 0.1} {
+			rng := rand.New(rand.NewSource(1))
+			x := randomBigIntSparse(rng, size, size, density)
+			y := randomBigIntSparse(rng, size, size, density)
+			name := fmt.Sprintf("%v_%v", size, density)
+
+			b.Run("gustavson_"+name, func(b *testing.B) {
+				mat := NewBigIntMat(size, size)
+				for n := 0; n < b.N; n++ {
+					mat.Mul(x, y)
+				}
+			})
+			b.Run("inner_product_"+name, func(b *testing.B) {
+				mat := NewBigIntMat(size, size)
+				for n := 0; n < b.N; n++ {
+					bigIntMulInnerProduct(mat, x, y)
+				}
+			})
+		}
+	}
+}
+
+func TestBigIntMatrix_Ownership(t *testing.T) {
+	values := intsToBigInts([]int{1, 2, 3, 4})
+	m := NewBigIntMat(2, 2, values...)
+	values[0].SetInt64(10)
+	if m.At(0, 0).Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("NewBigIntMat must copy its values")
+	}
+
+	value := big.NewInt(5)
+	m.Set(0, 1, value)
+	value.SetInt64(7)
+	if m.At(0, 1).Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("Set must copy its value")
+	}
+
+	m.SetNoCopy(1, 0, value)
+	if m.At(1, 0) != value {
+		t.Fatalf("SetNoCopy must store the value itself")
+	}
+
+	// results never alias values the caller can still reach through the inputs
+	a := NewBigIntMat(2, 2, intsToBigInts([]int{1, 0, 0, 1})...)
+	b := NewBigIntMat(2, 2, intsToBigInts([]int{0, 2, 0, -1})...)
+	sum := NewBigIntMat(2, 2)
+	sum.Add(a, b)
+	a.Negate()
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{1, 2, 0, 0})...)
+	if !sum.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, sum)
+	}
+}
+
+func TestBigIntMatrix_Add_Views(t *testing.T) {
+	m := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})...)
+	actual := NewBigIntMat(2, 2)
+	actual.Add(m.Slice(0, 0, 2, 2), m.Slice(1, 1, 2, 2))
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{6, 8, 12, 14})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_Sub(t *testing.T) {
+	a := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 0, 4, 0, 6})...)
+	b := NewBigIntMat(2, 3, intsToBigInts([]int{1, 0, 3, 5, 0, -6})...)
+	actual := NewBigIntMat(2, 3, intsToBigInts([]int{9, 9, 9, 9, 9, 9})...)
+	actual.Sub(a, b)
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{0, 2, -3, -1, 0, 12})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+	if actual.rowValues()[0][0] != nil {
+		t.Fatalf("expected zero difference not to be stored")
+	}
+
+	m := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 3, 4, 5, 6, 7, 8, 9})...)
+	views := NewBigIntMat(2, 2)
+	views.Sub(m.Slice(1, 1, 2, 2), m.Slice(0, 0, 2, 2))
+	expected = NewBigIntMat(2, 2, intsToBigInts([]int{4, 4, 4, 4})...)
+	if !views.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, views)
+	}
+}
+
+func TestBigIntMatrix_MulElem(t *testing.T) {
+	a := NewBigIntMat(2, 3, intsToBigInts([]int{1, 2, 0, 4, -5, 6})...)
+	b := NewBigIntMat(2, 3, intsToBigInts([]int{7, 0, 3, 2, 3, -1})...)
+	actual := NewBigIntMat(2, 3, intsToBigInts([]int{9, 9, 9, 9, 9, 9})...)
+	actual.MulElem(a, b)
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{7, 0, 0, 8, -15, -6})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	// the receiver may be one of the inputs
+	a.MulElem(a, a.T().T())
+	expected = NewBigIntMat(2, 3, intsToBigInts([]int{1, 4, 0, 16, 25, 36})...)
+	if !a.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, a)
+	}
+}
+
+func TestBigIntMatrix_Div(t *testing.T) {
+	tests := []struct {
+		mode     Rounding
+		expected []int
+	}{
+		//                      7/2 -7/2 7/-2 -7/-2 5/2 -5/2 9/3 1/3 -2/3 0/5
+		{RoundTowardZero, []int{3, -3, -3, 3, 2, -2, 3, 0, 0, 0}},
+		{RoundFloor, []int{3, -4, -4, 3, 2, -3, 3, 0, -1, 0}},
+		{RoundCeil, []int{4, -3, -3, 4, 3, -2, 3, 1, 0, 0}},
+		{RoundEuclidean, []int{3, -4, -3, 4, 2, -3, 3, 0, -1, 0}},
+		{RoundHalfAwayFromZero, []int{4, -4, -4, 4, 3, -3, 3, 0, -1, 0}},
+		{RoundHalfEven, []int{4, -4, -4, 4, 2, -2, 3, 0, -1, 0}},
+	}
+	a := NewBigIntMat(2, 5, intsToBigInts([]int{7, -7, 7, -7, 5, -5, 9, 1, -2, 0})...)
+	b := NewBigIntMat(2, 5, intsToBigInts([]int{2, 2, -2, -2, 2, 2, 3, 3, 3, 5})...)
+	for _, test := range tests {
+		t.Run(strconv.Itoa(int(test.mode)), func(t *testing.T) {
+			actual := NewBigIntMat(2, 5)
+			actual.Div(a, b, test.mode)
+			expected := NewBigIntMat(2, 5, intsToBigInts(test.expected)...)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+			}
+		})
+	}
+
+	actual := NewBigIntMat(2, 5)
+	actual.Quo(a, b)
+	expected := NewBigIntMat(2, 5, intsToBigInts(tests[0].expected)...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestBigIntMatrix_Div_Zero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected division by zero to panic")
+		}
+	}()
+	NewBigIntMat(2, 2).Quo(BigIntIdentity(2), BigIntIdentity(2))
+}
+
+func TestBigIntMatrix_Scale(t *testing.T) {
+	a := NewBigIntMat(2, 2, intsToBigInts([]int{1, 0, -3, 4})...)
+	actual := NewBigIntMat(2, 2, intsToBigInts([]int{9, 9, 9, 9})...)
+	actual.Scale(a, big.NewInt(-2))
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{-2, 0, 6, -8})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	actual.Scale(actual, big.NewInt(0))
+	if !actual.Equals(NewBigIntMat(2, 2)) {
+		t.Fatalf("expected zero matrix but found:\n%v", actual)
+	}
+}
+
+func TestBigIntMatrix_AddScalar(t *testing.T) {
+	a := NewBigIntMat(2, 2, intsToBigInts([]int{1, 0, -3, 4})...)
+	s := big.NewInt(3)
+	actual := NewBigIntMat(2, 2)
+	actual.AddScalar(a, s)
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{4, 3, 0, 7})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	// the scalar is copied
+	s.SetInt64(100)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	a.AddScalar(a, big.NewInt(0))
+	expected = NewBigIntMat(2, 2, intsToBigInts([]int{1, 0, -3, 4})...)
+	if !a.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, a)
+	}
+}
+
+func TestBigIntMatrix_Mod(t *testing.T) {
+	a := NewBigIntMat(2, 3, intsToBigInts([]int{7, -7, 0, 5, -10, 12})...)
+	actual := NewBigIntMat(2, 3)
+	actual.Mod(a, big.NewInt(5))
+	expected := NewBigIntMat(2, 3, intsToBigInts([]int{2, 3, 0, 0, 0, 2})...)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected non positive modulus to panic")
+		}
+	}()
+	actual.Mod(a, big.NewInt(0))
+}
+
+func TestBigIntMatrix_ScalarNil(t *testing.T) {
+	a := BigIntIdentity(2)
+	tests := []struct {
+		name     string
+		op       func(mat *BigIntMatrix)
+		expected string
+	}{
+		{"Scale", func(mat *BigIntMatrix) { mat.Scale(a, nil) }, "scale factor was found to be nil"},
+		{"AddScalar", func(mat *BigIntMatrix) { mat.AddScalar(a, nil) }, "scalar addition value was found to be nil"},
+		{"Mod", func(mat *BigIntMatrix) { mat.Mod(a, nil) }, "modulus was found to be nil"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != test.expected {
+					t.Fatalf("expected panic %q but found %v", test.expected, r)
+				}
+			}()
+			test.op(NewBigIntMat(2, 2))
+		})
+	}
+}
+
+func TestBigIntMatrix_Abs(t *testing.T) {
+	a := NewBigIntMat(2, 2, intsToBigInts([]int{-1, 0, 3, -4})...)
+	a.Abs(a)
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{1, 0, 3, 4})...)
+	if !a.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, a)
+	}
+}
@@ -100,7 +100,7 @@ func (vec *BigIntVector) at(absColIdx int) *big.Int {
 	// This replicates BigIntMatrix.at(r, c) for r = vec.mat.rowStart, c = absColIdx
 	// or rather, it's a direct access for String() if that pattern is kept.
 	// For consistency with BigIntMatrix's sparse nature:
-	if rowMap, ok := vec.mat.rowValues[vec.mat.rowStart]; ok {
+	if rowMap, ok := vec.mat.rowValues()[vec.mat.rowStart]; ok {
 		if val, ok2 := rowMap[absColIdx]; ok2 {
 			return val
 		}
@@ -108,8 +108,17 @@ func (vec *BigIntVector) at(absColIdx int) *big.Int {
 	return nil // Default to zero if not found
 }
 
-// Set sets the value at index i to value.
+// Set sets the value at index i to a copy of value.
 func (vec *BigIntVector) Set(i int, value *big.Int) {
+	if value != nil && value.Sign() != 0 {
+		value = new(big.Int).Set(value)
+	}
+	vec.SetNoCopy(i, value)
+}
+
+// SetNoCopy sets the value at index i to value without copying it. The vector takes ownership of
+// value, the caller must not modify it afterwards.
+func (vec *BigIntVector) SetNoCopy(i int, value *big.Int) {
 	vec.checkBounds(i)
 	j := i + vec.offset()
 	vec.set(j, value)
@@ -158,11 +167,11 @@ func (vec *BigIntVector) Dot(a *BigIntVector) *big.Int {
 
 func (vec *BigIntVector) NonzeroValues() (indexToValues map[int]*big.Int) {
 	indexToValues = make(map[int]*big.Int)
-	if vec.mat == nil || vec.mat.rowValues == nil {
+	if vec.mat == nil || vec.mat.rowValues() == nil {
 		return
 	}
 
-	rowMap, ok := vec.mat.rowValues[vec.mat.rowStart]
+	rowMap, ok := vec.mat.rowValues()[vec.mat.rowStart]
 	if !ok {
 		return
 	}
@@ -180,6 +189,22 @@ func (vec *BigIntVector) NonzeroValues() (indexToValues map[int]*big.Int) {
 	return
 }
 
+// SortedNonzeroValues returns the non zero indices in increasing order along with their associated values.
+func (vec *BigIntVector) SortedNonzeroValues() (indices []int, values []*big.Int) {
+	if vec.mat == nil || vec.mat.rowValues() == nil {
+		return
+	}
+
+	cs := vec.mat.rowValues()[vec.mat.rowStart]
+	indices = sortedBigIntInnerKeys(cs, vec.mat.colStart, vec.mat.cols)
+	values = make([]*big.Int, len(indices))
+	for k, c := range indices {
+		values[k] = cs[c]
+		indices[k] = c - vec.mat.colStart
+	}
+	return
+}
+
 func (vec *BigIntVector) T() *TransposedBigIntVector {
 	return &TransposedBigIntVector{
 		mat: vec.mat.T(),
@@ -236,6 +261,79 @@ func (tvec *TransposedBigIntVector) Add(a, b *TransposedBigIntVector) {
 
 	tvec.mat.add(a.mat, b.mat)
 }
+
+// Sub sets vec equal to the difference a-b.
+func (vec *BigIntVector) Sub(a, b *BigIntVector) {
+	vec.checkElementwise("subtraction", a, b)
+	if vec == a || vec == b {
+		panic("subtraction self assignment not allowed")
+	}
+
+	vec.mat.sub(a.mat, b.mat)
+}
+
+// MulElem sets vec equal to the elementwise product of a and b.
+func (vec *BigIntVector) MulElem(a, b *BigIntVector) {
+	vec.checkElementwise("elementwise multiply", a, b)
+
+	vec.mat.mulElem(a.mat, b.mat)
+}
+
+// Div sets vec equal to the elementwise quotient a/b rounded as given by mode. Every value of b must
+// be nonzero.
+func (vec *BigIntVector) Div(a, b *BigIntVector, mode Rounding) {
+	vec.checkElementwise("division", a, b)
+
+	vec.mat.Div(a.mat, b.mat, mode)
+}
+
+// Quo sets vec equal to the elementwise quotient a/b truncated toward zero. Every value of b must be
+// nonzero.
+func (vec *BigIntVector) Quo(a, b *BigIntVector) {
+	vec.Div(a, b, RoundTowardZero)
+}
+
+// Scale sets vec equal to a with every value multiplied by s.
+func (vec *BigIntVector) Scale(a *BigIntVector, s *big.Int) {
+	vec.checkElementwise("scale", a, a)
+
+	vec.mat.Scale(a.mat, s)
+}
+
+// AddScalar sets vec equal to a with s added to every value.
+func (vec *BigIntVector) AddScalar(a *BigIntVector, s *big.Int) {
+	vec.checkElementwise("scalar addition", a, a)
+
+	vec.mat.AddScalar(a.mat, s)
+}
+
+// Mod sets vec equal to a with every value reduced into [0,m). The modulus m must be positive.
+func (vec *BigIntVector) Mod(a *BigIntVector, m *big.Int) {
+	vec.checkElementwise("modulus", a, a)
+
+	vec.mat.Mod(a.mat, m)
+}
+
+// Abs sets vec equal to the absolute values of a.
+func (vec *BigIntVector) Abs(a *BigIntVector) {
+	vec.checkElementwise("absolute value", a, a)
+
+	vec.mat.Abs(a.mat)
+}
+
+func (vec *BigIntVector) checkElementwise(op string, a, b *BigIntVector) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+
+	if a.Len() != b.Len() {
+		panic(fmt.Sprintf("%v vectors must have the same length", op))
+	}
+	if vec.Len() != a.Len() {
+		panic(fmt.Sprintf("%v vectors, destination must have the same length", op))
+	}
+}
+
 func (vec *BigIntVector) Equals(v *BigIntVector) bool {
 	if vec == v {
 		return true
@@ -352,7 +450,7 @@ func (tvec *TransposedBigIntVector) At(i int) *big.Int {
 // internal at method, takes absolute row index
 func (tvec *TransposedBigIntVector) at(absRowIdx int) *big.Int {
 	// This replicates BigIntMatrix.at(r, c) for r = absRowIdx, c = tvec.mat.colStart
-	if rowMap, ok := tvec.mat.rowValues[absRowIdx]; ok {
+	if rowMap, ok := tvec.mat.rowValues()[absRowIdx]; ok {
 		if val, ok2 := rowMap[tvec.mat.colStart]; ok2 {
 			return val
 		}
@@ -360,13 +458,20 @@ func (tvec *TransposedBigIntVector) at(absRowIdx int) *big.Int {
 	return big.NewInt(0)
 }
 
-// Set sets the value at index i (row i for the column vector) to value.
+// Set sets the value at index i (row i for the column vector) to a copy of value.
 func (tvec *TransposedBigIntVector) Set(i int, value *big.Int) {
 	tvec.checkBounds(i)
 	// tvec.mat is an Nx1 view. Its row is i, its col is 0.
 	tvec.mat.Set(i, 0, value)
 }
 
+// SetNoCopy sets the value at index i to value without copying it. The vector takes ownership of
+// value, the caller must not modify it afterwards.
+func (tvec *TransposedBigIntVector) SetNoCopy(i int, value *big.Int) {
+	tvec.checkBounds(i)
+	tvec.mat.SetNoCopy(i, 0, value)
+}
+
 // internal set method, takes absolute row index
 func (tvec *TransposedBigIntVector) set(absRowIdx int, value *big.Int) {
 	tvec.mat.set(absRowIdx, tvec.mat.colStart, value)
@@ -442,14 +547,14 @@ func (tvec *TransposedBigIntVector) Equals(v *TransposedBigIntVector) bool {
 
 func (tvec *TransposedBigIntVector) NonzeroValues() (indexToValues map[int]*big.Int) {
 	indexToValues = make(map[int]*big.Int)
-	if tvec.mat == nil || tvec.mat.colValues == nil { // Transposed vector uses colValues of its matrix if it's a direct column view
+	if tvec.mat == nil || tvec.mat.colValues() == nil { // Transposed vector uses colValues of its matrix if it's a direct column view
 		return
 	}
 
 	// A transposed vector is represented by an N x 1 matrix.
 	// We are interested in the non-zero values in its single column (tvec.mat.colStart).
 	// The keys of indexToValues are the row indices (0 to Len-1).
-	colMap, ok := tvec.mat.colValues[tvec.mat.colStart]
+	colMap, ok := tvec.mat.colValues()[tvec.mat.colStart]
 	if !ok {
 		return
 	}
@@ -466,6 +571,22 @@ func (tvec *TransposedBigIntVector) NonzeroValues() (indexToValues map[int]*big.
 	return
 }
 
+// SortedNonzeroValues returns the non zero indices in increasing order along with their associated values.
+func (tvec *TransposedBigIntVector) SortedNonzeroValues() (indices []int, values []*big.Int) {
+	if tvec.mat == nil || tvec.mat.colValues() == nil {
+		return
+	}
+
+	rs := tvec.mat.colValues()[tvec.mat.colStart]
+	indices = sortedBigIntInnerKeys(rs, tvec.mat.rowStart, tvec.mat.rows)
+	values = make([]*big.Int, len(indices))
+	for k, r := range indices {
+		values[k] = rs[r]
+		indices[k] = r - tvec.mat.rowStart
+	}
+	return
+}
+
 func (tvec *TransposedBigIntVector) String() string {
 	buff := &strings.Builder{}
 	table := tablewriter.NewWriter(buff)
@@ -494,3 +615,75 @@ func (tvec *TransposedBigIntVector) Negate() {
 	}
 	tvec.mat.Negate()
 }
+
+// Sub sets tvec equal to the difference a-b.
+func (tvec *TransposedBigIntVector) Sub(a, b *TransposedBigIntVector) {
+	tvec.checkElementwise("subtraction", a, b)
+	if tvec == a || tvec == b {
+		panic("subtraction self assignment not allowed")
+	}
+
+	tvec.mat.sub(a.mat, b.mat)
+}
+
+// MulElem sets tvec equal to the elementwise product of a and b.
+func (tvec *TransposedBigIntVector) MulElem(a, b *TransposedBigIntVector) {
+	tvec.checkElementwise("elementwise multiply", a, b)
+
+	tvec.mat.mulElem(a.mat, b.mat)
+}
+
+// Div sets tvec equal to the elementwise quotient a/b rounded as given by mode. Every value of b must
+// be nonzero.
+func (tvec *TransposedBigIntVector) Div(a, b *TransposedBigIntVector, mode Rounding) {
+	tvec.checkElementwise("division", a, b)
+
+	tvec.mat.Div(a.mat, b.mat, mode)
+}
+
+// Quo sets tvec equal to the elementwise quotient a/b truncated toward zero. Every value of b must be
+// nonzero.
+func (tvec *TransposedBigIntVector) Quo(a, b *TransposedBigIntVector) {
+	tvec.Div(a, b, RoundTowardZero)
+}
+
+// Scale sets tvec equal to a with every value multiplied by s.
+func (tvec *TransposedBigIntVector) Scale(a *TransposedBigIntVector, s *big.Int) {
+	tvec.checkElementwise("scale", a, a)
+
+	tvec.mat.Scale(a.mat, s)
+}
+
+// AddScalar sets tvec equal to a with s added to every value.
+func (tvec *TransposedBigIntVector) AddScalar(a *TransposedBigIntVector, s *big.Int) {
+	tvec.checkElementwise("scalar addition", a, a)
+
+	tvec.mat.AddScalar(a.mat, s)
+}
+
+// Mod sets tvec equal to a with every value reduced into [0,m). The modulus m must be positive.
+func (tvec *TransposedBigIntVector) Mod(a *TransposedBigIntVector, m *big.Int) {
+	tvec.checkElementwise("modulus", a, a)
+
+	tvec.mat.Mod(a.mat, m)
+}
+
+// Abs sets tvec equal to the absolute values of a.
+func (tvec *TransposedBigIntVector) Abs(a *TransposedBigIntVector) {
+	tvec.checkElementwise("absolute value", a, a)
+
+	tvec.mat.Abs(a.mat)
+}
+
+func (tvec *TransposedBigIntVector) checkElementwise(op string, a, b *TransposedBigIntVector) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+
+	if a.Len() != b.Len() {
+		panic(fmt.Sprintf("%v transposed vectors must have the same length", op))
+	}
+	if tvec.Len() != a.Len() {
+		panic(fmt.Sprintf("%v transposed vectors, destination must have the same length", op))
+	}
+}
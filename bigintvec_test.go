@@ -154,6 +154,29 @@ func TestBigIntVector_NonzeroValues(t *testing.T) {
 	}
 }
 
+func TestBigIntVector_SortedNonzeroValues(t *testing.T) {
+	tests := []struct {
+		input           *BigIntVector
+		expectedIndices []int
+		expectedValues  []*big.Int
+	}{
+		{NewBigIntVec(4), []int{}, []*big.Int{}},
+		{NewBigIntVec(6, intsToBigInts([]int{0, 3, 0, 1, 0, 2})...), []int{1, 3, 5}, intsToBigInts([]int{3, 1, 2})},
+		{NewBigIntVec(6, intsToBigInts([]int{0, 3, 0, 1, 0, 2})...).Slice(2, 4), []int{1, 3}, intsToBigInts([]int{1, 2})},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			indices, values := test.input.SortedNonzeroValues()
+			if !reflect.DeepEqual(indices, test.expectedIndices) {
+				t.Fatalf("expected %v but found %v", test.expectedIndices, indices)
+			}
+			if !reflect.DeepEqual(values, test.expectedValues) {
+				t.Fatalf("expected %v but found %v", test.expectedValues, values)
+			}
+		})
+	}
+}
+
 func TestBigIntVector_Slice(t *testing.T) {
 	tests := []struct {
 		original         *BigIntVector
@@ -310,6 +333,29 @@ func TestTransposedBigIntVector_NonzeroValues(t *testing.T) {
 	}
 }
 
+func TestTransposedBigIntVector_SortedNonzeroValues(t *testing.T) {
+	tests := []struct {
+		input           *TransposedBigIntVector
+		expectedIndices []int
+		expectedValues  []*big.Int
+	}{
+		{NewTBigIntVec(4), []int{}, []*big.Int{}},
+		{NewTBigIntVec(6, intsToBigInts([]int{2, 0, 0, 1, 0, 4})...), []int{0, 3, 5}, intsToBigInts([]int{2, 1, 4})},
+		{NewTBigIntVec(6, intsToBigInts([]int{2, 0, 0, 1, 0, 4})...).Slice(3, 3), []int{0, 2}, intsToBigInts([]int{1, 4})},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			indices, values := test.input.SortedNonzeroValues()
+			if !reflect.DeepEqual(indices, test.expectedIndices) {
+				t.Fatalf("expected %v but found %v", test.expectedIndices, indices)
+			}
+			if !reflect.DeepEqual(values, test.expectedValues) {
+				t.Fatalf("expected %v but found %v", test.expectedValues, values)
+			}
+		})
+	}
+}
+
 func TestTransposedBigIntVector_Slice(t *testing.T) {
 	tests := []struct {
 		original         *TransposedBigIntVector
@@ -433,3 +479,80 @@ func TestTransposedBigIntVector_Negate(t *testing.T) {
 		})
 	}
 }
+
+func TestBigIntVector_SetNoCopy(t *testing.T) {
+	vec := NewBigIntVec(3)
+	value := big.NewInt(4)
+	vec.Set(0, value)
+	vec.SetNoCopy(1, value)
+	value.SetInt64(9)
+	if vec.At(0).Cmp(big.NewInt(4)) != 0 || vec.At(1).Cmp(big.NewInt(9)) != 0 {
+		t.Fatalf("expected 4 and 9 but found %v and %v", vec.At(0), vec.At(1))
+	}
+
+	tvec := NewBigIntVec(3).T()
+	value = big.NewInt(4)
+	tvec.Set(0, value)
+	tvec.SetNoCopy(1, value)
+	value.SetInt64(9)
+	if tvec.At(0).Cmp(big.NewInt(4)) != 0 || tvec.At(1).Cmp(big.NewInt(9)) != 0 {
+		t.Fatalf("expected 4 and 9 but found %v and %v", tvec.At(0), tvec.At(1))
+	}
+}
+
+func TestBigIntVector_Elementwise(t *testing.T) {
+	a := NewBigIntVec(4, intsToBigInts([]int{6, -7, 0, 3})...)
+	b := NewBigIntVec(4, intsToBigInts([]int{4, 2, 1, -2})...)
+	tests := []struct {
+		name     string
+		op       func(vec *BigIntVector)
+		expected []int
+	}{
+		{"Sub", func(vec *BigIntVector) { vec.Sub(a, b) }, []int{2, -9, -1, 5}},
+		{"MulElem", func(vec *BigIntVector) { vec.MulElem(a, b) }, []int{24, -14, 0, -6}},
+		{"Div", func(vec *BigIntVector) { vec.Div(a, b, RoundFloor) }, []int{1, -4, 0, -2}},
+		{"Quo", func(vec *BigIntVector) { vec.Quo(a, b) }, []int{1, -3, 0, -1}},
+		{"Scale", func(vec *BigIntVector) { vec.Scale(a, big.NewInt(2)) }, []int{12, -14, 0, 6}},
+		{"AddScalar", func(vec *BigIntVector) { vec.AddScalar(a, big.NewInt(7)) }, []int{13, 0, 7, 10}},
+		{"Mod", func(vec *BigIntVector) { vec.Mod(a, big.NewInt(4)) }, []int{2, 1, 0, 3}},
+		{"Abs", func(vec *BigIntVector) { vec.Abs(a) }, []int{6, 7, 0, 3}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := NewBigIntVec(4)
+			test.op(actual)
+			expected := NewBigIntVec(4, intsToBigInts(test.expected)...)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected %v but found %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestTransposedBigIntVector_Elementwise(t *testing.T) {
+	a := NewTBigIntVec(3, intsToBigInts([]int{5, -3, 0})...)
+	b := NewTBigIntVec(3, intsToBigInts([]int{2, 2, 9})...)
+	tests := []struct {
+		name     string
+		op       func(tvec *TransposedBigIntVector)
+		expected []int
+	}{
+		{"Sub", func(tvec *TransposedBigIntVector) { tvec.Sub(a, b) }, []int{3, -5, -9}},
+		{"MulElem", func(tvec *TransposedBigIntVector) { tvec.MulElem(a, b) }, []int{10, -6, 0}},
+		{"Div", func(tvec *TransposedBigIntVector) { tvec.Div(a, b, RoundHalfEven) }, []int{2, -2, 0}},
+		{"Scale", func(tvec *TransposedBigIntVector) { tvec.Scale(a, big.NewInt(-1)) }, []int{-5, 3, 0}},
+		{"AddScalar", func(tvec *TransposedBigIntVector) { tvec.AddScalar(a, big.NewInt(3)) }, []int{8, 0, 3}},
+		{"Mod", func(tvec *TransposedBigIntVector) { tvec.Mod(a, big.NewInt(3)) }, []int{2, 0, 0}},
+		{"Abs", func(tvec *TransposedBigIntVector) { tvec.Abs(a) }, []int{5, 3, 0}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := NewTBigIntVec(3)
+			test.op(actual)
+			expected := NewTBigIntVec(3, intsToBigInts(test.expected)...)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected %v but found %v", expected, actual)
+			}
+		})
+	}
+}
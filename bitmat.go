@@ -0,0 +1,392 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// m4rBits is the number of rows combined into each lookup table by the Method of Four Russians,
+// a table holds 2^m4rBits rows.
+const m4rBits = 8
+
+// BitMatrix is a dense matrix over GF(2) with every row packed into 64 bit words. It is meant for
+// dense linear algebra over GF(2), use NewBitMatFromMat and Matrix to convert from and to the
+// sparse Matrix.
+type BitMatrix struct {
+	rows   int
+	cols   int
+	stride int      // words per row
+	data   []uint64 // row i is data[i*stride : (i+1)*stride], column j is bit j%64 of word j/64
+}
+
+// NewBitMat returns a rows x cols zero matrix.
+func NewBitMat(rows, cols int) *BitMatrix {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Sprintf("matrix dimensions must be non-negative, got %dx%d", rows, cols))
+	}
+
+	stride := (cols + 63) / 64
+	return &BitMatrix{
+		rows:   rows,
+		cols:   cols,
+		stride: stride,
+		data:   make([]uint64, rows*stride),
+	}
+}
+
+// NewBitMatFromMat returns the packed form of m over GF(2), odd values become 1 and even values 0.
+func NewBitMatFromMat(m *Matrix) *BitMatrix {
+	bm := NewBitMat(m.Dims())
+	m.DoNonzero(func(i, j, value int) {
+		if value%2 != 0 {
+			bm.setBit(i, j)
+		}
+	})
+	return bm
+}
+
+// BitCopy returns a copy of m.
+func BitCopy(m *BitMatrix) *BitMatrix {
+	bm := NewBitMat(m.rows, m.cols)
+	copy(bm.data, m.data)
+	return bm
+}
+
+// BitIdentity returns a size x size identity matrix.
+func BitIdentity(size int) *BitMatrix {
+	bm := NewBitMat(size, size)
+	for i := 0; i < size; i++ {
+		bm.setBit(i, i)
+	}
+	return bm
+}
+
+// Matrix returns the matrix as a sparse Matrix of zeros and ones.
+func (bm *BitMatrix) Matrix() *Matrix {
+	m := NewMat(bm.rows, bm.cols)
+	for i := 0; i < bm.rows; i++ {
+		row := bm.row(i)
+		for w, word := range row {
+			for word != 0 {
+				b := bits.TrailingZeros64(word)
+				m.set(i, w*64+b, 1)
+				word &= word - 1
+			}
+		}
+	}
+	return m
+}
+
+// Dims returns the dimensions of the matrix.
+func (bm *BitMatrix) Dims() (int, int) {
+	return bm.rows, bm.cols
+}
+
+// At returns the value, 0 or 1, at row index i and column index j.
+func (bm *BitMatrix) At(i, j int) int {
+	bm.checkBounds(i, j)
+	return int(bm.data[i*bm.stride+j/64] >> uint(j%64) & 1)
+}
+
+// Set sets the value at row index i and column index j to value mod 2.
+func (bm *BitMatrix) Set(i, j, value int) {
+	bm.checkBounds(i, j)
+	if value%2 != 0 {
+		bm.setBit(i, j)
+	} else {
+		bm.data[i*bm.stride+j/64] &^= 1 << uint(j%64)
+	}
+}
+
+func (bm *BitMatrix) checkBounds(i, j int) {
+	if i < 0 || bm.rows <= i {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, bm.rows-1))
+	}
+	if j < 0 || bm.cols <= j {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", j, bm.cols-1))
+	}
+}
+
+func (bm *BitMatrix) setBit(i, j int) {
+	bm.data[i*bm.stride+j/64] |= 1 << uint(j%64)
+}
+
+func (bm *BitMatrix) row(i int) []uint64 {
+	return bm.data[i*bm.stride : (i+1)*bm.stride]
+}
+
+// bits returns the k <= 64 bits of row i starting at column j, column j in the lowest bit.
+func (bm *BitMatrix) bits(i, j, k int) uint64 {
+	row := bm.row(i)
+	w, off := j/64, uint(j%64)
+	x := row[w] >> off
+	if int(off)+k > 64 && w+1 < len(row) {
+		x |= row[w+1] << (64 - off)
+	}
+	if k == 64 {
+		return x
+	}
+	return x & (1<<uint(k) - 1)
+}
+
+func (bm *BitMatrix) swapRows(i, k int) {
+	if i == k {
+		return
+	}
+	ri, rk := bm.row(i), bm.row(k)
+	for w := range ri {
+		ri[w], rk[w] = rk[w], ri[w]
+	}
+}
+
+// xorRow sets dst to dst xor src.
+func xorRow(dst, src []uint64) {
+	for w := range dst {
+		dst[w] ^= src[w]
+	}
+}
+
+// Equals return true if m has the same shape and values as this matrix.
+func (bm *BitMatrix) Equals(m *BitMatrix) bool {
+	if bm.rows != m.rows || bm.cols != m.cols {
+		return false
+	}
+	for k, w := range bm.data {
+		if m.data[k] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// Mul stores the product of a and b over GF(2) in this matrix using the Method of Four Russians
+// (M4RM). Rows of b are taken m4rBits at a time and every combination of them is tabulated, so each
+// row of the result needs one table lookup per group instead of one row addition per set bit.
+func (bm *BitMatrix) Mul(a, b *BitMatrix) {
+	if a == nil || b == nil {
+		panic("multiply input was found to be nil")
+	}
+
+	if bm == a || bm == b {
+		panic("multiply self assignment not allowed")
+	}
+
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("multiply shape misalignment can't multiply (%v,%v)x(%v,%v)", a.rows, a.cols, b.rows, b.cols))
+	}
+
+	if bm.rows != a.rows || bm.cols != b.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", bm.rows, bm.cols, a.rows, b.cols))
+	}
+
+	for k := range bm.data {
+		bm.data[k] = 0
+	}
+
+	table := make([]uint64, (1<<m4rBits)*b.stride)
+	for start := 0; start < b.rows; start += m4rBits {
+		k := m4rBits
+		if b.rows-start < k {
+			k = b.rows - start
+		}
+
+		// table[v] is the sum of the rows start+t of b for every bit t set in v
+		for v := 1; v < 1<<uint(k); v++ {
+			low := bits.TrailingZeros(uint(v))
+			entry := table[v*b.stride : (v+1)*b.stride]
+			copy(entry, table[(v&(v-1))*b.stride:])
+			xorRow(entry, b.row(start+low))
+		}
+
+		for i := 0; i < a.rows; i++ {
+			v := int(a.bits(i, start, k))
+			if v != 0 {
+				xorRow(bm.row(i), table[v*b.stride:(v+1)*b.stride])
+			}
+		}
+	}
+}
+
+// mulVec returns the product of the square matrix and the column vector v, both vectors packed like
+// a row.
+func (bm *BitMatrix) mulVec(v []uint64) []uint64 {
+	product := make([]uint64, bm.stride)
+	for i := 0; i < bm.rows; i++ {
+		parity := 0
+		for w, x := range bm.row(i) {
+			parity ^= bits.OnesCount64(x & v[w])
+		}
+		if parity&1 == 1 {
+			product[i/64] |= 1 << uint(i%64)
+		}
+	}
+	return product
+}
+
+// pow returns the square matrix raised to the power k >= 0 using exponentiation by squaring.
+func (bm *BitMatrix) pow(k *big.Int) *BitMatrix {
+	result := BitIdentity(bm.rows)
+	currentPower := bm // the original matrix is never written to
+
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			temp := NewBitMat(bm.rows, bm.cols)
+			temp.Mul(result, currentPower)
+			result = temp
+		}
+		if i+1 < k.BitLen() {
+			temp := NewBitMat(bm.rows, bm.cols)
+			temp.Mul(currentPower, currentPower)
+			currentPower = temp
+		}
+	}
+	return result
+}
+
+// Echelonize brings the matrix into row echelon form over GF(2) in place and returns its rank.
+// When full is true the form is reduced, every pivot is the only 1 in its column.
+//
+// This is the Method of Four Russians inversion (M4RI) approach: pivots are searched for m4rBits
+// columns at a time, the pivot rows found are tabulated in every combination and the remaining
+// rows are cleared with one table lookup each.
+func (bm *BitMatrix) Echelonize(full bool) int {
+	table := make([]uint64, (1<<m4rBits)*bm.stride)
+	r := 0
+	for c := 0; c < bm.cols && r < bm.rows; c += m4rBits {
+		k := m4rBits
+		if bm.cols-c < k {
+			k = bm.cols - c
+		}
+
+		pivots := bm.blockPivots(r, c, k)
+		if len(pivots) == 0 {
+			continue
+		}
+
+		// table[v] clears the pivot columns of any row whose bits in the block are v
+		var pivotRow [m4rBits]int
+		for t := range pivotRow {
+			pivotRow[t] = -1
+		}
+		for p, col := range pivots {
+			pivotRow[col-c] = r + p
+		}
+		for v := 1; v < 1<<uint(k); v++ {
+			low := bits.TrailingZeros(uint(v))
+			entry := table[v*bm.stride : (v+1)*bm.stride]
+			copy(entry, table[(v&(v-1))*bm.stride:])
+			if pivotRow[low] >= 0 {
+				xorRow(entry, bm.row(pivotRow[low]))
+			}
+		}
+
+		from := r + len(pivots)
+		if full {
+			from = 0
+		}
+		for i := from; i < bm.rows; i++ {
+			if r <= i && i < r+len(pivots) {
+				continue
+			}
+			v := int(bm.bits(i, c, k))
+			if v != 0 {
+				xorRow(bm.row(i), table[v*bm.stride:(v+1)*bm.stride])
+			}
+		}
+		r += len(pivots)
+	}
+	return r
+}
+
+// blockPivots finds the pivots in the k columns starting at c among the rows from r, moves the
+// pivot rows to r, r+1, ... and reduces them against each other so each pivot is the only 1 of the
+// pivot rows in its column. It returns the pivot columns in order.
+func (bm *BitMatrix) blockPivots(r, c, k int) []int {
+	var pivots []int
+	for col := c; col < c+k; col++ {
+		p := r + len(pivots)
+		found := false
+		for i := p; i < bm.rows; i++ {
+			// rows below the pivots found so far are only reduced once they are looked at
+			for q, pc := range pivots {
+				if bm.bits(i, pc, 1) == 1 {
+					xorRow(bm.row(i), bm.row(r+q))
+				}
+			}
+			if bm.bits(i, col, 1) == 1 {
+				bm.swapRows(i, p)
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+
+		for q := range pivots {
+			if bm.bits(r+q, col, 1) == 1 {
+				xorRow(bm.row(r+q), bm.row(p))
+			}
+		}
+		pivots = append(pivots, col)
+	}
+	return pivots
+}
+
+// Rank returns the rank of the matrix over GF(2).
+func (bm *BitMatrix) Rank() int {
+	return BitCopy(bm).Echelonize(false)
+}
+
+// Inverse returns the inverse of the matrix over GF(2), or false if the matrix is singular. The
+// matrix must be square.
+func (bm *BitMatrix) Inverse() (*BitMatrix, bool) {
+	if bm.rows != bm.cols {
+		panic(fmt.Sprintf("matrix must be square to invert, got %dx%d", bm.rows, bm.cols))
+	}
+
+	n := bm.rows
+	augmented := NewBitMat(n, 2*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if bm.At(i, j) == 1 {
+				augmented.setBit(i, j)
+			}
+		}
+		augmented.setBit(i, n+i)
+	}
+
+	augmented.Echelonize(true)
+	inverse := NewBitMat(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if augmented.bits(i, j, 1) != boolBit(i == j) {
+				return nil, false
+			}
+			if augmented.bits(i, n+j, 1) == 1 {
+				inverse.setBit(i, j)
+			}
+		}
+	}
+	return inverse, true
+}
+
+func boolBit(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (bm BitMatrix) String() string {
+	var sb strings.Builder
+	for i := 0; i < bm.rows; i++ {
+		for j := 0; j < bm.cols; j++ {
+			sb.WriteByte('0' + byte(bm.bits(i, j, 1)))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
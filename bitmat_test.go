@@ -0,0 +1,195 @@
+package intmat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomBits(rng *rand.Rand, rows, cols int, density float64) *Matrix {
+	m := NewMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if rng.Float64() < density {
+				m.Set(i, j, 1)
+			}
+		}
+	}
+	return m
+}
+
+// rref returns the reduced row echelon form of m over GF(2) and its rank using plain Gaussian
+// elimination.
+func rref(m *Matrix) (*Matrix, int) {
+	rows, cols := m.Dims()
+	a := make([][]int, rows)
+	for i := range a {
+		a[i] = make([]int, cols)
+		for j := range a[i] {
+			a[i][j] = m.At(i, j) & 1
+		}
+	}
+
+	r := 0
+	for c := 0; c < cols && r < rows; c++ {
+		p := r
+		for p < rows && a[p][c] == 0 {
+			p++
+		}
+		if p == rows {
+			continue
+		}
+		a[r], a[p] = a[p], a[r]
+		for i := range a {
+			if i != r && a[i][c] == 1 {
+				for j := range a[i] {
+					a[i][j] ^= a[r][j]
+				}
+			}
+		}
+		r++
+	}
+
+	result := NewMat(rows, cols)
+	for i := range a {
+		for j := range a[i] {
+			result.Set(i, j, a[i][j])
+		}
+	}
+	return result, r
+}
+
+func TestNewBitMatFromMat(t *testing.T) {
+	m := NewMat(2, 70)
+	m.Set(0, 0, 3)
+	m.Set(0, 69, 1)
+	m.Set(1, 64, -1)
+	m.Set(1, 5, 2)
+
+	bm := NewBitMatFromMat(m)
+	if bm.At(0, 0) != 1 || bm.At(0, 69) != 1 || bm.At(1, 64) != 1 || bm.At(1, 5) != 0 {
+		t.Fatalf("unexpected values:\n%v", bm)
+	}
+
+	expected := NewMat(2, 70)
+	expected.Set(0, 0, 1)
+	expected.Set(0, 69, 1)
+	expected.Set(1, 64, 1)
+	if !bm.Matrix().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, bm.Matrix())
+	}
+
+	bm.Set(0, 69, 4)
+	if bm.At(0, 69) != 0 {
+		t.Fatalf("expected 0 but found %v", bm.At(0, 69))
+	}
+}
+
+func TestBitMatrix_Mul(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	tests := []struct {
+		rows, inner, cols int
+		density           float64
+	}{
+		{1, 1, 1, 1},
+		{3, 5, 4, 0.5},
+		{70, 130, 90, 0.5},
+		{64, 64, 64, 0.1},
+		{20, 200, 129, 0.02},
+	}
+	for _, test := range tests {
+		a := randomBits(rng, test.rows, test.inner, test.density)
+		b := randomBits(rng, test.inner, test.cols, test.density)
+
+		expected := NewMat(test.rows, test.cols)
+		expected.Mul(a, b)
+		expected.mod(2)
+
+		actual := NewBitMat(test.rows, test.cols)
+		actual.Set(0, 0, 1) // stale values must be cleared
+		actual.Mul(NewBitMatFromMat(a), NewBitMatFromMat(b))
+		if !actual.Matrix().Equals(expected) {
+			t.Fatalf("%dx%d times %dx%d expected:\n%v\nbut found:\n%v", test.rows, test.inner, test.inner, test.cols, expected, actual)
+		}
+	}
+}
+
+func TestBitMatrix_Echelonize(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	tests := []struct {
+		rows, cols int
+		density    float64
+	}{
+		{1, 1, 1},
+		{5, 5, 0},
+		{8, 12, 0.5},
+		{40, 30, 0.05},
+		{100, 100, 0.5},
+		{90, 150, 0.1},
+		{150, 90, 0.02},
+	}
+	for _, test := range tests {
+		m := randomBits(rng, test.rows, test.cols, test.density)
+		expected, expectedRank := rref(m)
+
+		reduced := NewBitMatFromMat(m)
+		rank := reduced.Echelonize(true)
+		if rank != expectedRank {
+			t.Fatalf("%dx%d expected rank %v but found %v", test.rows, test.cols, expectedRank, rank)
+		}
+		if !reduced.Matrix().Equals(expected) {
+			t.Fatalf("%dx%d expected:\n%v\nbut found:\n%v", test.rows, test.cols, expected, reduced)
+		}
+
+		// the unreduced form has the same row space so reducing it further gives the same result
+		echelon := NewBitMatFromMat(m)
+		if rank := echelon.Echelonize(false); rank != expectedRank {
+			t.Fatalf("%dx%d expected rank %v but found %v", test.rows, test.cols, expectedRank, rank)
+		}
+		lead := -1
+		for i := 0; i < test.rows; i++ {
+			j := 0
+			for j < test.cols && echelon.At(i, j) == 0 {
+				j++
+			}
+			if i >= expectedRank {
+				if j != test.cols {
+					t.Fatalf("expected row %v to be zero:\n%v", i, echelon)
+				}
+				continue
+			}
+			if j <= lead {
+				t.Fatalf("row %v is not in echelon form:\n%v", i, echelon)
+			}
+			lead = j
+		}
+		if again, _ := rref(echelon.Matrix()); !again.Equals(expected) {
+			t.Fatalf("%dx%d expected:\n%v\nbut found:\n%v", test.rows, test.cols, expected, again)
+		}
+
+		if rank := NewBitMatFromMat(m).Rank(); rank != expectedRank {
+			t.Fatalf("%dx%d expected rank %v but found %v", test.rows, test.cols, expectedRank, rank)
+		}
+	}
+}
+
+func TestBitMatrix_Inverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, n := range []int{1, 7, 70} {
+		for tries := 0; tries < 10; tries++ {
+			m := NewBitMatFromMat(randomBits(rng, n, n, 0.5))
+			inverse, ok := m.Inverse()
+			if ok != (m.Rank() == n) {
+				t.Fatalf("expected invertible %v but found %v", m.Rank() == n, ok)
+			}
+			if !ok {
+				continue
+			}
+
+			product := NewBitMat(n, n)
+			product.Mul(m, inverse)
+			if !product.Equals(BitIdentity(n)) {
+				t.Fatalf("expected identity but found:\n%v", product)
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Command benchtable turns `go test -bench` output into tables. Given one file it lists every
+// benchmark, given two it compares them side by side with the relative change:
+//
+//	go test -run xxx -bench Ops -benchmem -count 5 > old.txt
+//	# make changes
+//	go test -run xxx -bench Ops -benchmem -count 5 > new.txt
+//	go run ./cmd/benchtable old.txt new.txt
+//
+// Repeated runs of a benchmark (-count) are averaged.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// procsSuffix is the -GOMAXPROCS suffix go test appends to benchmark names.
+var procsSuffix = regexp.MustCompile(`-\d+$`)
+
+// results maps a benchmark name to its metrics, like "ns/op", averaged over runs.
+type results map[string]map[string]float64
+
+// parse reads benchmark lines from r and averages repeated runs.
+func parse(r io.Reader) (results, error) {
+	sums := results{}
+	counts := map[string]map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+
+		name := procsSuffix.ReplaceAllString(fields[0], "")
+		if sums[name] == nil {
+			sums[name] = map[string]float64{}
+			counts[name] = map[string]int{}
+		}
+		for k := 2; k+1 < len(fields); k += 2 {
+			value, err := strconv.ParseFloat(fields[k], 64)
+			if err != nil {
+				return nil, fmt.Errorf("benchmark %v: %v", name, err)
+			}
+			sums[name][fields[k+1]] += value
+			counts[name][fields[k+1]]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for name, metrics := range sums {
+		for metric := range metrics {
+			metrics[metric] /= float64(counts[name][metric])
+		}
+	}
+	return sums, nil
+}
+
+func parseFile(path string) (results, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parse(f)
+}
+
+func names(rs ...results) []string {
+	seen := map[string]bool{}
+	var ns []string
+	for _, r := range rs {
+		for name := range r {
+			if !seen[name] {
+				seen[name] = true
+				ns = append(ns, name)
+			}
+		}
+	}
+	sort.Strings(ns)
+	return ns
+}
+
+func format(metrics map[string]float64, metric string) string {
+	value, ok := metrics[metric]
+	if !ok {
+		return "-"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// delta returns the relative change from old to cur as a signed percentage.
+func delta(old, cur map[string]float64, metric string) string {
+	o, ok1 := old[metric]
+	n, ok2 := cur[metric]
+	if !ok1 || !ok2 {
+		return "-"
+	}
+	if o == 0 {
+		if n == 0 {
+			return "+0.00%"
+		}
+		return "+Inf%"
+	}
+	return fmt.Sprintf("%+.2f%%", (n-o)/o*100)
+}
+
+func newTable(w io.Writer, header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	alignment := make([]int, len(header))
+	alignment[0] = tablewriter.ALIGN_LEFT
+	for k := 1; k < len(alignment); k++ {
+		alignment[k] = tablewriter.ALIGN_RIGHT
+	}
+	table.SetColumnAlignment(alignment)
+	return table
+}
+
+// list writes a table with the given metrics of every benchmark.
+func list(w io.Writer, r results, metrics []string) {
+	table := newTable(w, append([]string{"benchmark"}, metrics...))
+	for _, name := range names(r) {
+		row := []string{name}
+		for _, metric := range metrics {
+			row = append(row, format(r[name], metric))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// compare writes a table with old, current and the change of every metric of every benchmark.
+func compare(w io.Writer, old, cur results, metrics []string) {
+	header := []string{"benchmark"}
+	for _, metric := range metrics {
+		header = append(header, "old "+metric, "new "+metric, "delta")
+	}
+	table := newTable(w, header)
+	for _, name := range names(old, cur) {
+		row := []string{name}
+		for _, metric := range metrics {
+			row = append(row, format(old[name], metric), format(cur[name], metric), delta(old[name], cur[name], metric))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+func main() {
+	metricsFlag := flag.String("metrics", "ns/op,B/op,allocs/op", "comma separated metrics to show")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %v [flags] results.txt [new-results.txt]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	metrics := strings.Split(*metricsFlag, ",")
+
+	var rs []results
+	for _, path := range flag.Args() {
+		r, err := parseFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		rs = append(rs, r)
+	}
+
+	if len(rs) == 1 {
+		list(os.Stdout, rs[0], metrics)
+		return
+	}
+	compare(os.Stdout, rs[0], rs[1], metrics)
+}
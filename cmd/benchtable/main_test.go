@@ -0,0 +1,61 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const oldOutput = `goos: linux
+goarch: amd64
+pkg: github.com/nathanhack/intmat
+BenchmarkMatrixOps/Mul/32x32_0.5-8         	    1000	      2000 ns/op	     512 B/op	       8 allocs/op
+BenchmarkMatrixOps/Mul/32x32_0.5-8         	    1000	      4000 ns/op	     512 B/op	       8 allocs/op
+BenchmarkMatrixOps/Add/32x32_0.5-8         	    5000	       300 ns/op
+PASS
+ok  	github.com/nathanhack/intmat	2.534s
+`
+
+const newOutput = `BenchmarkMatrixOps/Mul/32x32_0.5-8         	    1000	      1500 ns/op	     256 B/op	       8 allocs/op
+BenchmarkMatrixOps/XOr/32x32_0.5-8         	    1000	       100 ns/op
+`
+
+func TestParse(t *testing.T) {
+	actual, err := parse(strings.NewReader(oldOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := results{
+		"BenchmarkMatrixOps/Mul/32x32_0.5": {"ns/op": 3000, "B/op": 512, "allocs/op": 8},
+		"BenchmarkMatrixOps/Add/32x32_0.5": {"ns/op": 300},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	old, err := parse(strings.NewReader(oldOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := parse(strings.NewReader(newOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	compare(&sb, old, new, []string{"ns/op"})
+	lines := strings.Split(sb.String(), "\n")
+	expected := []string{
+		"BenchmarkMatrixOps/Add/32x32_0.5 | 300 | - | -",
+		"BenchmarkMatrixOps/Mul/32x32_0.5 | 3000 | 1500 | -50.00%",
+		"BenchmarkMatrixOps/XOr/32x32_0.5 | - | 100 | -",
+	}
+	for k, e := range expected {
+		row := strings.Join(strings.Fields(strings.Trim(lines[3+k], "| ")), " ")
+		if row != e {
+			t.Fatalf("expected row %q but found %q in\n%v", e, row, sb.String())
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package intmat
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// The methods in this file treat a square matrix as the adjacency matrix of a directed graph,
+// a nonzero value at (i,j) is an edge from vertex i to vertex j. Neighbors are always visited in
+// increasing vertex order so results are the same from run to run.
+
+// BFS returns the vertices reachable from start in breadth first order.
+func (mat *Matrix) BFS(start int) []int {
+	mat.checkAdjacency("BFS")
+	mat.checkRowBounds(start)
+
+	visited := make([]bool, mat.rows)
+	visited[start] = true
+	order := []int{start}
+	for next := 0; next < len(order); next++ {
+		for _, v := range mat.successors(order[next]) {
+			if !visited[v] {
+				visited[v] = true
+				order = append(order, v)
+			}
+		}
+	}
+	return order
+}
+
+// DFS returns the vertices reachable from start in depth first (preorder) order.
+func (mat *Matrix) DFS(start int) []int {
+	mat.checkAdjacency("DFS")
+	mat.checkRowBounds(start)
+
+	visited := make([]bool, mat.rows)
+	var order []int
+	stack := []int{start}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[u] {
+			continue
+		}
+		visited[u] = true
+		order = append(order, u)
+
+		// push in reverse so the lowest neighbor is visited first
+		vs := mat.successors(u)
+		for k := len(vs) - 1; k >= 0; k-- {
+			if !visited[vs[k]] {
+				stack = append(stack, vs[k])
+			}
+		}
+	}
+	return order
+}
+
+// ConnectedComponents returns the connected components of the graph with edge directions
+// ignored. Each component is sorted and components are ordered by their lowest vertex.
+func (mat *Matrix) ConnectedComponents() [][]int {
+	mat.checkAdjacency("connected components")
+
+	visited := make([]bool, mat.rows)
+	var components [][]int
+	for s := 0; s < mat.rows; s++ {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		component := []int{s}
+		for next := 0; next < len(component); next++ {
+			u := component[next]
+			for _, vs := range [][]int{mat.successors(u), mat.predecessors(u)} {
+				for _, v := range vs {
+					if !visited[v] {
+						visited[v] = true
+						component = append(component, v)
+					}
+				}
+			}
+		}
+		sort.Ints(component)
+		components = append(components, component)
+	}
+	return components
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph using
+// Tarjan's algorithm. Each component is sorted and the components are in reverse topological
+// order, no component has an edge to a component listed after it.
+func (mat *Matrix) StronglyConnectedComponents() [][]int {
+	mat.checkAdjacency("strongly connected components")
+
+	index := make([]int, mat.rows)
+	low := make([]int, mat.rows)
+	onStack := make([]bool, mat.rows)
+	for v := range index {
+		index[v] = -1
+	}
+
+	var components [][]int
+	var stack []int
+	next := 0
+
+	var connect func(v int)
+	connect = func(v int) {
+		index[v] = next
+		low[v] = next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range mat.successors(v) {
+			switch {
+			case index[w] < 0:
+				connect(w)
+				if low[w] < low[v] {
+					low[v] = low[w]
+				}
+			case onStack[w] && index[w] < low[v]:
+				low[v] = index[w]
+			}
+		}
+
+		if low[v] != index[v] {
+			return
+		}
+		var component []int
+		for {
+			w := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[w] = false
+			component = append(component, w)
+			if w == v {
+				break
+			}
+		}
+		sort.Ints(component)
+		components = append(components, component)
+	}
+
+	for v := 0; v < mat.rows; v++ {
+		if index[v] < 0 {
+			connect(v)
+		}
+	}
+	return components
+}
+
+// TopologicalSort returns the vertices ordered so that every edge goes from an earlier vertex to
+// a later one, choosing the lowest available vertex at each step. It returns false if the graph
+// has a cycle (including a self loop).
+func (mat *Matrix) TopologicalSort() ([]int, bool) {
+	mat.checkAdjacency("topological sort")
+
+	inDegree := make([]int, mat.rows)
+	ready := &intHeap{}
+	for v := 0; v < mat.rows; v++ {
+		inDegree[v] = len(mat.predecessors(v))
+		if inDegree[v] == 0 {
+			heap.Push(ready, v)
+		}
+	}
+
+	order := make([]int, 0, mat.rows)
+	for ready.Len() > 0 {
+		u := heap.Pop(ready).(int)
+		order = append(order, u)
+		for _, v := range mat.successors(u) {
+			inDegree[v]--
+			if inDegree[v] == 0 {
+				heap.Push(ready, v)
+			}
+		}
+	}
+
+	if len(order) != mat.rows {
+		return nil, false
+	}
+	return order, true
+}
+
+// IsBipartite reports whether the graph, with edge directions ignored, can be 2-colored. When it
+// can, colors holds a 0 or 1 for every vertex with the lowest vertex of each component colored 0.
+func (mat *Matrix) IsBipartite() (colors []int, ok bool) {
+	mat.checkAdjacency("bipartite check")
+
+	colors = make([]int, mat.rows)
+	for v := range colors {
+		colors[v] = -1
+	}
+
+	for s := 0; s < mat.rows; s++ {
+		if colors[s] >= 0 {
+			continue
+		}
+		colors[s] = 0
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, vs := range [][]int{mat.successors(u), mat.predecessors(u)} {
+				for _, v := range vs {
+					switch {
+					case colors[v] < 0:
+						colors[v] = 1 - colors[u]
+						queue = append(queue, v)
+					case colors[v] == colors[u]:
+						return nil, false
+					}
+				}
+			}
+		}
+	}
+	return colors, true
+}
+
+func (mat *Matrix) checkAdjacency(op string) {
+	if mat.rows != mat.cols {
+		panic(fmt.Sprintf("matrix must be square for %v, got %dx%d", op, mat.rows, mat.cols))
+	}
+}
+
+// successors returns, in increasing order, the vertices with an edge from vertex i.
+func (mat *Matrix) successors(i int) []int {
+	js := sortedInnerKeys(mat.rowValues()[i+mat.rowStart], mat.colStart, mat.cols)
+	for k := range js {
+		js[k] -= mat.colStart
+	}
+	return js
+}
+
+// predecessors returns, in increasing order, the vertices with an edge to vertex j.
+func (mat *Matrix) predecessors(j int) []int {
+	is := sortedInnerKeys(mat.colValues()[j+mat.colStart], mat.rowStart, mat.rows)
+	for k := range is {
+		is[k] -= mat.rowStart
+	}
+	return is
+}
+
+// intHeap is a min-heap of ints for use with container/heap.
+type intHeap []int
+
+func (h intHeap) Len() int            { return len(h) }
+func (h intHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x interface{}) { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,152 @@
+package intmat
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// graph returns an n vertex adjacency matrix holding the given edges.
+func graph(n int, edges ...[2]int) *Matrix {
+	m := NewMat(n, n)
+	for _, e := range edges {
+		m.Set(e[0], e[1], 1)
+	}
+	return m
+}
+
+func TestMatrix_BFS(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		start    int
+		expected []int
+	}{
+		{graph(1), 0, []int{0}},
+		{graph(5, [2]int{0, 2}, [2]int{0, 1}, [2]int{1, 3}, [2]int{2, 4}, [2]int{4, 0}), 0, []int{0, 1, 2, 3, 4}},
+		{graph(5, [2]int{0, 2}, [2]int{0, 1}, [2]int{1, 3}, [2]int{2, 4}, [2]int{4, 0}), 2, []int{2, 4, 0, 1, 3}},
+		{graph(4, [2]int{1, 0}, [2]int{2, 3}), 0, []int{0}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.BFS(test.start)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_DFS(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		start    int
+		expected []int
+	}{
+		{graph(1), 0, []int{0}},
+		{graph(5, [2]int{0, 2}, [2]int{0, 1}, [2]int{1, 3}, [2]int{2, 4}, [2]int{4, 0}), 0, []int{0, 1, 3, 2, 4}},
+		{graph(4, [2]int{0, 1}, [2]int{0, 2}, [2]int{1, 2}, [2]int{2, 3}), 0, []int{0, 1, 2, 3}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.DFS(test.start)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_ConnectedComponents(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		expected [][]int
+	}{
+		{graph(3), [][]int{{0}, {1}, {2}}},
+		{graph(5, [2]int{3, 0}, [2]int{1, 4}), [][]int{{0, 3}, {1, 4}, {2}}},
+		{graph(4, [2]int{3, 2}, [2]int{2, 1}, [2]int{0, 1}), [][]int{{0, 1, 2, 3}}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.ConnectedComponents()
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_StronglyConnectedComponents(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		expected [][]int
+	}{
+		{graph(3), [][]int{{0}, {1}, {2}}},
+		{graph(3, [2]int{0, 1}, [2]int{1, 2}), [][]int{{2}, {1}, {0}}},
+		{graph(5, [2]int{0, 1}, [2]int{1, 2}, [2]int{2, 0}, [2]int{2, 3}, [2]int{3, 4}, [2]int{4, 3}), [][]int{{3, 4}, {0, 1, 2}}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.StronglyConnectedComponents()
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_TopologicalSort(t *testing.T) {
+	tests := []struct {
+		m          *Matrix
+		expected   []int
+		expectedOk bool
+	}{
+		{graph(3), []int{0, 1, 2}, true},
+		{graph(4, [2]int{3, 1}, [2]int{1, 0}, [2]int{2, 0}), []int{2, 3, 1, 0}, true},
+		{graph(3, [2]int{0, 1}, [2]int{1, 2}, [2]int{2, 0}), nil, false},
+		{graph(2, [2]int{1, 1}), nil, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, ok := test.m.TopologicalSort()
+			if ok != test.expectedOk {
+				t.Fatalf("expected %v but found %v", test.expectedOk, ok)
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_IsBipartite(t *testing.T) {
+	tests := []struct {
+		m          *Matrix
+		expected   []int
+		expectedOk bool
+	}{
+		{graph(3), []int{0, 0, 0}, true},
+		{graph(4, [2]int{0, 1}, [2]int{2, 1}, [2]int{2, 3}, [2]int{3, 0}), []int{0, 1, 0, 1}, true},
+		{graph(3, [2]int{0, 1}, [2]int{1, 2}, [2]int{0, 2}), nil, false},
+		{graph(1, [2]int{0, 0}), nil, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual, ok := test.m.IsBipartite()
+			if ok != test.expectedOk {
+				t.Fatalf("expected %v but found %v", test.expectedOk, ok)
+			}
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_Graph_Slice(t *testing.T) {
+	m := graph(4, [2]int{1, 2}, [2]int{2, 3}, [2]int{0, 1})
+	actual := m.Slice(1, 1, 3, 3).BFS(0)
+	expected := []int{0, 1, 2}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
@@ -0,0 +1,173 @@
+package ldpc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nathanhack/intmat"
+)
+
+// maxLLR bounds the messages passed by the sum-product decoder so atanh stays finite.
+const maxLLR = 30.0
+
+// SumProduct decodes using belief propagation with the sum-product (tanh rule) check node
+// update. llr holds the channel log-likelihood ratios, a positive value favors a 0 bit and a
+// negative value favors a 1 bit. Decoding stops once the hard decision satisfies every check
+// or maxIterations have been performed.
+func SumProduct(h *intmat.Matrix, llr []float64, maxIterations int) DecodeResult {
+	return beliefPropagation(h, llr, maxIterations, sumProductCheck)
+}
+
+// MinSum decodes using belief propagation with the normalized min-sum check node update, every
+// check to variable message is scaled by alpha (typically in (0,1], 1 gives plain min-sum).
+// See SumProduct for the meaning of llr and maxIterations.
+func MinSum(h *intmat.Matrix, llr []float64, alpha float64, maxIterations int) DecodeResult {
+	if alpha <= 0 {
+		panic("min-sum normalization alpha must be positive")
+	}
+
+	return beliefPropagation(h, llr, maxIterations, func(in, out []float64) {
+		minSumCheck(in, out, alpha)
+	})
+}
+
+// beliefPropagation runs a flooding schedule over the Tanner graph of h. Messages are held per
+// edge, edges are numbered check by check, and checkUpdate computes the outgoing messages of a
+// single check from its incoming ones.
+func beliefPropagation(h *intmat.Matrix, llr []float64, maxIterations int, checkUpdate func(in, out []float64)) DecodeResult {
+	if h == nil {
+		panic("belief propagation input was found to be nil")
+	}
+	_, cols := h.Dims()
+	if len(llr) != cols {
+		panic(fmt.Sprintf("llr length (%v) does not match code length (%v)", len(llr), cols))
+	}
+	if maxIterations < 0 {
+		panic("max iterations must be non-negative")
+	}
+
+	checks, _ := tanner(h)
+
+	// edgeStart[i] is the first edge of check i, varEdges[j] lists the edges of variable j.
+	edgeStart := make([]int, len(checks)+1)
+	varEdges := make([][]int, cols)
+	for i, vs := range checks {
+		edgeStart[i+1] = edgeStart[i] + len(vs)
+		for k, j := range vs {
+			varEdges[j] = append(varEdges[j], edgeStart[i]+k)
+		}
+	}
+	edges := edgeStart[len(checks)]
+	edgeVar := make([]int, edges)
+	for i, vs := range checks {
+		copy(edgeVar[edgeStart[i]:], vs)
+	}
+
+	toCheck := make([]float64, edges)
+	toVar := make([]float64, edges)
+	for e, j := range edgeVar {
+		toCheck[e] = llr[j]
+	}
+
+	z := hardDecision(llr)
+	syndrome := syndromeOf(checks, z)
+
+	iterations := 0
+	for ; iterations < maxIterations && !isZero(syndrome); iterations++ {
+		for i := range checks {
+			checkUpdate(toCheck[edgeStart[i]:edgeStart[i+1]], toVar[edgeStart[i]:edgeStart[i+1]])
+		}
+
+		for j, es := range varEdges {
+			total := llr[j]
+			for _, e := range es {
+				total += toVar[e]
+			}
+			for _, e := range es {
+				toCheck[e] = total - toVar[e]
+			}
+
+			z[j] = 0
+			if total < 0 {
+				z[j] = 1
+			}
+		}
+		syndrome = syndromeOf(checks, z)
+	}
+
+	return DecodeResult{
+		Codeword:   vecFromBits(z),
+		Iterations: iterations,
+		Converged:  isZero(syndrome),
+	}
+}
+
+// sumProductCheck applies the tanh rule, excluding each edge's own message by way of
+// prefix and suffix products so that no division is needed.
+func sumProductCheck(in, out []float64) {
+	n := len(in)
+	if n == 0 {
+		return
+	}
+
+	t := make([]float64, n)
+	for k, v := range in {
+		t[k] = math.Tanh(clamp(v) / 2)
+	}
+
+	prefix := 1.0
+	for k := 0; k < n; k++ {
+		out[k] = prefix
+		prefix *= t[k]
+	}
+	suffix := 1.0
+	for k := n - 1; k >= 0; k-- {
+		out[k] = clamp(2 * math.Atanh(clampUnit(out[k]*suffix)))
+		suffix *= t[k]
+	}
+}
+
+// minSumCheck gives each edge the product of the other signs times the smallest other
+// magnitude, scaled by alpha.
+func minSumCheck(in, out []float64, alpha float64) {
+	min1, min2 := math.Inf(1), math.Inf(1)
+	minIndex := -1
+	sign := 1.0
+	for k, v := range in {
+		if v < 0 {
+			sign = -sign
+		}
+		m := math.Abs(v)
+		if m < min1 {
+			min1, min2 = m, min1
+			minIndex = k
+		} else if m < min2 {
+			min2 = m
+		}
+	}
+
+	for k, v := range in {
+		m := min1
+		if k == minIndex {
+			m = min2
+		}
+		if math.IsInf(m, 1) {
+			m = maxLLR
+		}
+
+		s := sign
+		if v < 0 {
+			s = -s
+		}
+		out[k] = alpha * s * m
+	}
+}
+
+func clamp(v float64) float64 {
+	return math.Max(-maxLLR, math.Min(maxLLR, v))
+}
+
+func clampUnit(v float64) float64 {
+	const limit = 1 - 1e-15
+	return math.Max(-limit, math.Min(limit, v))
+}
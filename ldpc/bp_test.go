@@ -0,0 +1,78 @@
+package ldpc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nathanhack/intmat"
+)
+
+func TestSumProduct(t *testing.T) {
+	tests := []struct {
+		llr                []float64
+		maxIterations      int
+		expected           *intmat.Vector
+		expectedIterations int
+		expectedConverged  bool
+	}{
+		{[]float64{2, 2, 2, 2, 2, 2, 2}, 10, intmat.NewVec(7), 0, true},
+		{[]float64{2, 2, 2, 2, 2, -0.5, 2}, 10, intmat.NewVec(7), 1, true},
+		{[]float64{-2, 2, 2, 1.5, -2, 0.5, 2}, 10, intmat.NewVec(7, 1, 0, 0, 0, 1, 1, 0), 1, true},
+		{[]float64{2, 2, 2, 2, 2, -0.5, 2}, 0, intmat.NewVec(7, 0, 0, 0, 0, 0, 1, 0), 0, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := SumProduct(hamming74(), test.llr, test.maxIterations)
+			if actual.Converged != test.expectedConverged {
+				t.Fatalf("expected converged %v but found %v", test.expectedConverged, actual.Converged)
+			}
+			if actual.Iterations != test.expectedIterations {
+				t.Fatalf("expected %v iterations but found %v", test.expectedIterations, actual.Iterations)
+			}
+			if !actual.Codeword.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual.Codeword)
+			}
+		})
+	}
+}
+
+func TestMinSum(t *testing.T) {
+	tests := []struct {
+		llr                []float64
+		alpha              float64
+		expected           *intmat.Vector
+		expectedIterations int
+	}{
+		{[]float64{2, 2, 2, 2, 2, 2, 2}, 1, intmat.NewVec(7), 0},
+		{[]float64{2, 2, 2, 2, 2, -0.5, 2}, 1, intmat.NewVec(7), 1},
+		{[]float64{2, 2, 2, 2, 2, -0.5, 2}, 0.75, intmat.NewVec(7), 1},
+		{[]float64{-2, 2, 2, 1.5, -2, 0.5, 2}, 0.75, intmat.NewVec(7, 1, 0, 0, 0, 1, 1, 0), 1},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := MinSum(hamming74(), test.llr, test.alpha, 10)
+			if !actual.Converged {
+				t.Fatalf("expected decoder to converge")
+			}
+			if actual.Iterations != test.expectedIterations {
+				t.Fatalf("expected %v iterations but found %v", test.expectedIterations, actual.Iterations)
+			}
+			if !actual.Codeword.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual.Codeword)
+			}
+		})
+	}
+}
+
+func TestMinSumCheck(t *testing.T) {
+	in := []float64{1.5, -0.5, 2}
+	out := make([]float64, len(in))
+	minSumCheck(in, out, 0.5)
+
+	expected := []float64{-0.25, 0.75, -0.25}
+	for k := range expected {
+		if out[k] != expected[k] {
+			t.Fatalf("expected %v but found %v", expected, out)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package ldpc
+
+import (
+	"fmt"
+
+	"github.com/nathanhack/intmat"
+)
+
+// Code is a binary linear block code described by its parity-check matrix and a
+// systematic generator matrix derived from it.
+type Code struct {
+	H    *intmat.Matrix     // (n-k)xn (or taller when H has redundant rows) parity-check matrix
+	G    *intmat.Matrix     // kxn systematic generator [I_k | P], columns are ordered by Perm
+	Perm intmat.Permutation // column j of G corresponds to column Perm[j] of H
+	K    int                // dimension of the code
+	N    int                // length of the code
+}
+
+// NewCode derives a systematic generator matrix for the code with parity-check matrix h.
+// The values in h are taken mod 2. Redundant rows of h are allowed, the dimension of the
+// code is n minus the rank of h over GF(2).
+func NewCode(h *intmat.Matrix) *Code {
+	if h == nil {
+		panic("parity-check matrix was found to be nil")
+	}
+
+	m, n := h.Dims()
+	a := mod2(h)
+
+	// Gauss-Jordan elimination over GF(2), keeping track of the pivot columns.
+	pivots := make([]int, 0, m)
+	isPivot := make([]bool, n)
+	r := 0
+	for j := 0; j < n && r < m; j++ {
+		rs, _ := a.Column(j).SortedNonzeroValues()
+		p := -1
+		for _, i := range rs {
+			if i >= r {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		a.SwapRows(p, r)
+
+		pivot := a.Row(r)
+		rs, _ = a.Column(j).SortedNonzeroValues()
+		for _, i := range rs {
+			if i == r {
+				continue
+			}
+			tmp := intmat.NewVec(n)
+			tmp.XOr(a.Row(i), pivot)
+			a.SetRow(i, tmp)
+		}
+
+		pivots = append(pivots, j)
+		isPivot[j] = true
+		r++
+	}
+
+	k := n - r
+	perm := make(intmat.Permutation, 0, n)
+	freeIndex := make(map[int]int, k)
+	for j := 0; j < n; j++ {
+		if !isPivot[j] {
+			freeIndex[j] = len(perm)
+			perm = append(perm, j)
+		}
+	}
+	perm = append(perm, pivots...)
+
+	// Every pivot bit is the sum of the free bits found in its row of the reduced matrix.
+	g := intmat.NewMat(k, n)
+	for t := 0; t < k; t++ {
+		g.Set(t, t, 1)
+	}
+	for i := 0; i < r; i++ {
+		cs, _ := a.Row(i).SortedNonzeroValues()
+		for _, c := range cs {
+			t, free := freeIndex[c]
+			if !free {
+				continue
+			}
+			g.Set(t, k+i, 1)
+		}
+	}
+
+	return &Code{
+		H:    h,
+		G:    g,
+		Perm: perm,
+		K:    k,
+		N:    n,
+	}
+}
+
+// Encode returns the codeword for msg. The codeword is in the column order of H, so
+// the message bits are found at the positions Perm[0:K].
+func (code *Code) Encode(msg *intmat.Vector) *intmat.Vector {
+	if msg == nil {
+		panic("message was found to be nil")
+	}
+	if msg.Len() != code.K {
+		panic(fmt.Sprintf("message length (%v) does not match code dimension (%v)", msg.Len(), code.K))
+	}
+
+	codeword := intmat.NewVec(code.N)
+	codeword.Mul(msg, code.G)
+	code.Perm.ApplyVec(codeword)
+
+	js, values := codeword.SortedNonzeroValues()
+	for k, j := range js {
+		codeword.Set(j, values[k]&1)
+	}
+	return codeword
+}
+
+// Message returns the message bits held in the systematic positions of codeword.
+func (code *Code) Message(codeword *intmat.Vector) *intmat.Vector {
+	if codeword == nil {
+		panic("codeword was found to be nil")
+	}
+	if codeword.Len() != code.N {
+		panic(fmt.Sprintf("codeword length (%v) does not match code length (%v)", codeword.Len(), code.N))
+	}
+
+	msg := intmat.NewVec(code.K)
+	for t := 0; t < code.K; t++ {
+		msg.Set(t, codeword.At(code.Perm[t])&1)
+	}
+	return msg
+}
+
+// mod2 returns a new matrix holding the values of m reduced mod 2.
+func mod2(m *intmat.Matrix) *intmat.Matrix {
+	rows, cols := m.Dims()
+	result := intmat.NewMat(rows, cols)
+	m.DoNonzero(func(i, j, value int) {
+		if value&1 == 1 {
+			result.Set(i, j, 1)
+		}
+	})
+	return result
+}
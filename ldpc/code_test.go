@@ -0,0 +1,82 @@
+package ldpc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nathanhack/intmat"
+)
+
+func hamming74() *intmat.Matrix {
+	return intmat.NewMat(3, 7,
+		1, 1, 0, 1, 1, 0, 0,
+		1, 0, 1, 1, 0, 1, 0,
+		0, 1, 1, 1, 0, 0, 1,
+	)
+}
+
+func isCodeword(h *intmat.Matrix, c *intmat.Vector) bool {
+	rows, _ := h.Dims()
+	s := intmat.NewVec(rows)
+	s.Mul(c, h.T())
+	for i := 0; i < rows; i++ {
+		if s.At(i)&1 == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func bitsOf(value, length int) *intmat.Vector {
+	v := intmat.NewVec(length)
+	for i := 0; i < length; i++ {
+		v.Set(i, (value>>i)&1)
+	}
+	return v
+}
+
+func TestNewCode(t *testing.T) {
+	tests := []struct {
+		h         *intmat.Matrix
+		expectedK int
+	}{
+		{hamming74(), 4},
+		{intmat.NewMat(4, 7,
+			1, 1, 0, 1, 1, 0, 0,
+			1, 0, 1, 1, 0, 1, 0,
+			0, 1, 1, 1, 0, 0, 1,
+			0, 1, 1, 0, 1, 1, 0,
+		), 4},
+		{intmat.NewMat(2, 4, 1, 1, 1, 1, 0, 0, 1, 1), 2},
+		{intmat.NewMat(1, 3), 3},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			code := NewCode(test.h)
+			if code.K != test.expectedK {
+				t.Fatalf("expected k=%v but found %v", test.expectedK, code.K)
+			}
+
+			g := intmat.Copy(code.G)
+			if !g.Slice(0, 0, code.K, code.K).Equals(intmat.Identity(code.K)) {
+				t.Fatalf("expected systematic generator but found \n%v", code.G)
+			}
+
+			seen := map[string]bool{}
+			for m := 0; m < 1<<code.K; m++ {
+				msg := bitsOf(m, code.K)
+				c := code.Encode(msg)
+				if !isCodeword(test.h, c) {
+					t.Fatalf("expected %v to be a codeword", c)
+				}
+				if !code.Message(c).Equals(msg) {
+					t.Fatalf("expected message %v but found %v", msg, code.Message(c))
+				}
+				seen[c.String()] = true
+			}
+			if len(seen) != 1<<code.K {
+				t.Fatalf("expected %v distinct codewords but found %v", 1<<code.K, len(seen))
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package ldpc
+
+import (
+	"github.com/nathanhack/intmat"
+)
+
+// Edge is an edge of a Tanner graph, joining check node Check (a row of H) and variable node Var (a column of H).
+type Edge struct {
+	Check, Var int
+}
+
+// CycleCounts holds the number of short cycles in a Tanner graph.
+type CycleCounts struct {
+	Four, Six, Eight int
+
+	// Edges holds, for every edge on at least one short cycle, the number of 4-, 6- and 8-cycles
+	// passing through it (in that order).
+	Edges map[Edge][3]int
+}
+
+// Girth returns the length of the shortest cycle in the Tanner graph of h, or 0 when the graph
+// has no cycles. Values of h are taken mod 2.
+func Girth(h *intmat.Matrix) int {
+	if h == nil {
+		panic("girth input was found to be nil")
+	}
+
+	checks, vars := tanner(h)
+	n := len(vars)
+
+	// nodes 0..n-1 are variables and n.. are checks
+	neighbors := func(u int) []int {
+		if u < n {
+			return vars[u]
+		}
+		return checks[u-n]
+	}
+	id := func(u, w int) int {
+		if u < n {
+			return w + n
+		}
+		return w
+	}
+
+	girth := 0
+	dist := make([]int, n+len(checks))
+	parent := make([]int, n+len(checks))
+	for s := 0; s < n; s++ {
+		for u := range dist {
+			dist[u] = -1
+		}
+		dist[s] = 0
+		parent[s] = -1
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			// any cycle found from here on is no shorter than 2*dist[u]+1
+			if girth != 0 && 2*dist[u]+1 >= girth {
+				break
+			}
+
+			for _, w := range neighbors(u) {
+				w = id(u, w)
+				if w == parent[u] {
+					continue
+				}
+				if dist[w] < 0 {
+					dist[w] = dist[u] + 1
+					parent[w] = u
+					queue = append(queue, w)
+					continue
+				}
+
+				length := dist[u] + dist[w] + 1
+				if girth == 0 || length < girth {
+					girth = length
+				}
+			}
+		}
+	}
+	return girth
+}
+
+// Cycles counts the 4-, 6- and 8-cycles in the Tanner graph of h and the number of those cycles
+// passing through each edge. Values of h are taken mod 2.
+func Cycles(h *intmat.Matrix) CycleCounts {
+	if h == nil {
+		panic("cycles input was found to be nil")
+	}
+
+	checks, vars := tanner(h)
+
+	// Every cycle is walked from its lowest variable node, once in each direction.
+	var counts [3]int
+	edges := map[Edge][3]int{}
+	onPath := make([]bool, len(checks))
+	visitedVar := make([]bool, len(vars))
+	path := make([]int, 0, 8) // alternating variable, check, variable, ...
+
+	record := func(s int) {
+		k := (len(path) - 4) / 2
+		counts[k]++
+		for p := 1; p < len(path); p += 2 {
+			next := s
+			if p+1 < len(path) {
+				next = path[p+1]
+			}
+			for _, e := range []Edge{{path[p], path[p-1]}, {path[p], next}} {
+				c := edges[e]
+				c[k]++
+				edges[e] = c
+			}
+		}
+	}
+
+	var walk func(s, v int)
+	walk = func(s, v int) {
+		for _, c := range vars[v] {
+			if onPath[c] {
+				continue
+			}
+			onPath[c] = true
+			path = append(path, c)
+
+			for _, w := range checks[c] {
+				if w == s && len(path) >= 4 {
+					record(s)
+					continue
+				}
+				if w <= s || visitedVar[w] || len(path) >= 8 {
+					continue
+				}
+				visitedVar[w] = true
+				path = append(path, w)
+				walk(s, w)
+				path = path[:len(path)-1]
+				visitedVar[w] = false
+			}
+
+			path = path[:len(path)-1]
+			onPath[c] = false
+		}
+	}
+
+	for s := range vars {
+		visitedVar[s] = true
+		path = append(path[:0], s)
+		walk(s, s)
+		visitedVar[s] = false
+	}
+
+	for e, c := range edges {
+		edges[e] = [3]int{c[0] / 2, c[1] / 2, c[2] / 2}
+	}
+	return CycleCounts{
+		Four:  counts[0] / 2,
+		Six:   counts[1] / 2,
+		Eight: counts[2] / 2,
+		Edges: edges,
+	}
+}
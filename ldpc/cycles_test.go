@@ -0,0 +1,98 @@
+package ldpc
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/nathanhack/intmat"
+)
+
+func ones(rows, cols int) *intmat.Matrix {
+	m := intmat.NewMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			m.Set(i, j, 1)
+		}
+	}
+	return m
+}
+
+func TestGirth(t *testing.T) {
+	tests := []struct {
+		h        *intmat.Matrix
+		expected int
+	}{
+		{intmat.Identity(4), 0},
+		{intmat.NewMat(2, 3, 1, 1, 0, 0, 1, 1), 0},
+		{ones(2, 2), 4},
+		{intmat.NewMat(3, 3, 1, 1, 0, 0, 1, 1, 1, 0, 1), 6},
+		{intmat.NewMat(4, 4, 1, 1, 0, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 0, 0, 1), 8},
+		{hamming74(), 4},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Girth(test.h)
+			if actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCycles(t *testing.T) {
+	tests := []struct {
+		h                  *intmat.Matrix
+		four, six, eight   int
+		edge               Edge
+		expectedEdgeCounts [3]int
+		expectedEdgesOnAny int
+	}{
+		{intmat.Identity(4), 0, 0, 0, Edge{0, 0}, [3]int{}, 0},
+		{ones(2, 2), 1, 0, 0, Edge{1, 0}, [3]int{1, 0, 0}, 4},
+		{ones(2, 3), 3, 0, 0, Edge{0, 2}, [3]int{2, 0, 0}, 6},
+		{ones(3, 3), 9, 6, 0, Edge{2, 1}, [3]int{4, 4, 0}, 9},
+		{ones(4, 4), 36, 96, 72, Edge{3, 0}, [3]int{9, 36, 36}, 16},
+		{intmat.NewMat(4, 4, 1, 1, 0, 0, 0, 1, 1, 0, 0, 0, 1, 1, 1, 0, 0, 1), 0, 0, 1, Edge{3, 3}, [3]int{0, 0, 1}, 8},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Cycles(test.h)
+			if actual.Four != test.four || actual.Six != test.six || actual.Eight != test.eight {
+				t.Fatalf("expected (%v,%v,%v) but found (%v,%v,%v)", test.four, test.six, test.eight, actual.Four, actual.Six, actual.Eight)
+			}
+			if actual.Edges[test.edge] != test.expectedEdgeCounts {
+				t.Fatalf("expected %v but found %v", test.expectedEdgeCounts, actual.Edges[test.edge])
+			}
+			if len(actual.Edges) != test.expectedEdgesOnAny {
+				t.Fatalf("expected %v edges on a cycle but found %v", test.expectedEdgesOnAny, len(actual.Edges))
+			}
+		})
+	}
+}
+
+func TestCycles_Girth(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 10; i++ {
+		h := Bernoulli(rng, 6, 10, 0.3)
+		counts := Cycles(h)
+
+		expected := 0
+		switch {
+		case counts.Four > 0:
+			expected = 4
+		case counts.Six > 0:
+			expected = 6
+		case counts.Eight > 0:
+			expected = 8
+		}
+
+		actual := Girth(h)
+		if expected != 0 && actual != expected {
+			t.Fatalf("expected girth %v but found %v for \n%v", expected, actual, h)
+		}
+		if expected == 0 && actual != 0 && actual <= 8 {
+			t.Fatalf("expected girth above 8 but found %v for \n%v", actual, h)
+		}
+	}
+}
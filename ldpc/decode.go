@@ -0,0 +1,183 @@
+package ldpc
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nathanhack/intmat"
+)
+
+// DecodeResult holds the outcome of an iterative decoder.
+type DecodeResult struct {
+	Codeword   *intmat.Vector // hard decision at the time the decoder stopped
+	Iterations int            // number of iterations performed
+	Converged  bool           // true when Codeword satisfies every parity check
+}
+
+// Syndrome returns H*r^T over GF(2).
+func Syndrome(h *intmat.Matrix, r *intmat.Vector) *intmat.TransposedVector {
+	if h == nil || r == nil {
+		panic("syndrome input was found to be nil")
+	}
+
+	rows, _ := h.Dims()
+	s := intmat.NewTVec(rows)
+	s.MulVec(h, r.T())
+
+	is, values := s.SortedNonzeroValues()
+	for k, i := range is {
+		s.Set(i, values[k]&1)
+	}
+	return s
+}
+
+// BitFlip decodes the hard decision word r using Gallager's bit-flipping algorithm. Each
+// iteration flips every bit that takes part in the largest number of unsatisfied checks.
+// Decoding stops once all checks are satisfied or maxIterations have been performed.
+func BitFlip(h *intmat.Matrix, r *intmat.Vector, maxIterations int) DecodeResult {
+	if h == nil || r == nil {
+		panic("bit flip input was found to be nil")
+	}
+	_, cols := h.Dims()
+	if r.Len() != cols {
+		panic(fmt.Sprintf("received word length (%v) does not match code length (%v)", r.Len(), cols))
+	}
+	if maxIterations < 0 {
+		panic("max iterations must be non-negative")
+	}
+
+	checks, vars := tanner(h)
+	z := make([]int, cols)
+	for j := range z {
+		z[j] = r.At(j) & 1
+	}
+	syndrome := syndromeOf(checks, z)
+
+	iterations := 0
+	for ; iterations < maxIterations && !isZero(syndrome); iterations++ {
+		unsatisfied := make([]int, cols)
+		most := 0
+		for j, cs := range vars {
+			for _, i := range cs {
+				unsatisfied[j] += syndrome[i]
+			}
+			if unsatisfied[j] > most {
+				most = unsatisfied[j]
+			}
+		}
+
+		for j := range z {
+			if unsatisfied[j] != most {
+				continue
+			}
+			flip(j, z, syndrome, vars)
+		}
+	}
+
+	return DecodeResult{
+		Codeword:   vecFromBits(z),
+		Iterations: iterations,
+		Converged:  isZero(syndrome),
+	}
+}
+
+// WeightedBitFlip decodes using the weighted bit-flipping algorithm. llr holds the channel
+// log-likelihood ratios, a positive value favors a 0 bit and a negative value favors a 1 bit.
+// Each iteration flips the single bit with the largest weighted count of unsatisfied checks,
+// where a check is weighted by the least reliable bit taking part in it.
+func WeightedBitFlip(h *intmat.Matrix, llr []float64, maxIterations int) DecodeResult {
+	if h == nil {
+		panic("weighted bit flip input was found to be nil")
+	}
+	_, cols := h.Dims()
+	if len(llr) != cols {
+		panic(fmt.Sprintf("llr length (%v) does not match code length (%v)", len(llr), cols))
+	}
+	if maxIterations < 0 {
+		panic("max iterations must be non-negative")
+	}
+
+	checks, vars := tanner(h)
+	z := hardDecision(llr)
+	syndrome := syndromeOf(checks, z)
+
+	weights := make([]float64, len(checks))
+	for i, vs := range checks {
+		weights[i] = math.Inf(1)
+		for _, j := range vs {
+			weights[i] = math.Min(weights[i], math.Abs(llr[j]))
+		}
+	}
+
+	iterations := 0
+	for ; iterations < maxIterations && !isZero(syndrome); iterations++ {
+		best := -1
+		bestMetric := math.Inf(-1)
+		for j, cs := range vars {
+			metric := 0.0
+			for _, i := range cs {
+				metric += float64(2*syndrome[i]-1) * weights[i]
+			}
+			if metric > bestMetric {
+				best = j
+				bestMetric = metric
+			}
+		}
+		if best < 0 {
+			break
+		}
+		flip(best, z, syndrome, vars)
+	}
+
+	return DecodeResult{
+		Codeword:   vecFromBits(z),
+		Iterations: iterations,
+		Converged:  isZero(syndrome),
+	}
+}
+
+func vecFromBits(bits []int) *intmat.Vector {
+	vec := intmat.NewVec(len(bits))
+	for j, b := range bits {
+		if b != 0 {
+			vec.Set(j, b)
+		}
+	}
+	return vec
+}
+
+func hardDecision(llr []float64) []int {
+	z := make([]int, len(llr))
+	for j, l := range llr {
+		if l < 0 {
+			z[j] = 1
+		}
+	}
+	return z
+}
+
+func syndromeOf(checks [][]int, z []int) []int {
+	syndrome := make([]int, len(checks))
+	for i, vs := range checks {
+		for _, j := range vs {
+			syndrome[i] ^= z[j]
+		}
+	}
+	return syndrome
+}
+
+func flip(j int, z, syndrome []int, vars [][]int) {
+	z[j] ^= 1
+	for _, i := range vars[j] {
+		syndrome[i] ^= 1
+	}
+}
+
+func isZero(bits []int) bool {
+	for _, b := range bits {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
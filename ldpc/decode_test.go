@@ -0,0 +1,90 @@
+package ldpc
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/nathanhack/intmat"
+)
+
+func TestSyndrome(t *testing.T) {
+	tests := []struct {
+		h        *intmat.Matrix
+		r        *intmat.Vector
+		expected *intmat.TransposedVector
+	}{
+		{hamming74(), intmat.NewVec(7), intmat.NewTVec(3)},
+		{hamming74(), intmat.NewVec(7, 1, 1, 1, 0, 0, 0, 1), intmat.NewTVec(3, 0, 0, 1)},
+		{hamming74(), intmat.NewVec(7, 0, 0, 0, 1, 0, 0, 0), intmat.NewTVec(3, 1, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Syndrome(test.h, test.r)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBitFlip(t *testing.T) {
+	code := NewCode(hamming74())
+	codeword := code.Encode(intmat.NewVec(4, 1, 0, 1, 1))
+
+	tests := []struct {
+		errors             []int
+		maxIterations      int
+		expectedIterations int
+		expectedConverged  bool
+	}{
+		{nil, 10, 0, true},
+		{[]int{3}, 10, 1, true},
+		{[]int{0}, 10, 2, true},
+		{[]int{3}, 0, 0, false},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			r := intmat.CopyVec(codeword)
+			for _, j := range test.errors {
+				r.Set(j, r.At(j)^1)
+			}
+
+			actual := BitFlip(code.H, r, test.maxIterations)
+			if actual.Iterations != test.expectedIterations {
+				t.Fatalf("expected %v iterations but found %v", test.expectedIterations, actual.Iterations)
+			}
+			if actual.Converged != test.expectedConverged {
+				t.Fatalf("expected converged %v but found %v", test.expectedConverged, actual.Converged)
+			}
+			if test.expectedConverged && !actual.Codeword.Equals(codeword) {
+				t.Fatalf("expected %v but found %v", codeword, actual.Codeword)
+			}
+		})
+	}
+}
+
+func TestWeightedBitFlip(t *testing.T) {
+	tests := []struct {
+		llr                []float64
+		expected           *intmat.Vector
+		expectedIterations int
+	}{
+		{[]float64{2, 2, 2, 2, 2, 2, 2}, intmat.NewVec(7), 0},
+		{[]float64{2, 2, 2, 2, 2, -0.5, 2}, intmat.NewVec(7), 1},
+		{[]float64{-2, 2, 2, 2, -2, 0.5, 2}, intmat.NewVec(7, 1, 0, 0, 0, 1, 1, 0), 1},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := WeightedBitFlip(hamming74(), test.llr, 10)
+			if !actual.Converged {
+				t.Fatalf("expected decoder to converge")
+			}
+			if actual.Iterations != test.expectedIterations {
+				t.Fatalf("expected %v iterations but found %v", test.expectedIterations, actual.Iterations)
+			}
+			if !actual.Codeword.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual.Codeword)
+			}
+		})
+	}
+}
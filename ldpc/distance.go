@@ -0,0 +1,338 @@
+package ldpc
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+
+	"github.com/nathanhack/intmat"
+)
+
+// WeightDistribution returns the number of codewords of each Hamming weight 0..n for the code
+// spanned by the rows of the generator matrix g (taken mod 2, dependent rows are allowed).
+// Every one of the 2^k codewords is visited so this is only practical for small k.
+// For a code given by a parity-check matrix use Code.WeightDistribution.
+func WeightDistribution(g *intmat.Matrix) []int {
+	if g == nil {
+		panic("weight distribution input was found to be nil")
+	}
+
+	_, n := g.Dims()
+	basis, _ := systematic(packRows(g), n, identityPerm(n))
+	k := len(basis)
+	if k >= 63 {
+		panic(fmt.Sprintf("code dimension (%v) too large to enumerate", k))
+	}
+
+	distribution := make([]int, n+1)
+	codeword := make([]uint64, words(n))
+	distribution[0] = 1
+	// walk the codewords in Gray code order so each step adds a single basis row
+	for i := uint64(1); i < 1<<uint(k); i++ {
+		xor(codeword, basis[bits.TrailingZeros64(i)])
+		distribution[weight(codeword)]++
+	}
+	return distribution
+}
+
+// MinDistance returns the minimum Hamming distance of the code spanned by the rows of the
+// generator matrix g (taken mod 2), or 0 when the code has no nonzero codewords.
+//
+// It uses the Brouwer-Zimmermann algorithm: generator matrices that are systematic on (as far
+// as possible) disjoint information sets are built, then messages of weight 1,2,... are
+// enumerated in each of them. After weight w every codeword not yet seen has weight at least
+// the sum over the matrices of w+1 minus the number of information positions that matrix
+// shares with earlier ones, so the search stops as soon as that bound reaches the lightest
+// codeword found. The run time grows quickly with k.
+//
+// Unlike most of this package MinDistance takes a generator matrix, not a parity-check matrix.
+// For a code given by a parity-check matrix use Code.MinDistance.
+func MinDistance(g *intmat.Matrix) int {
+	if g == nil {
+		panic("min distance input was found to be nil")
+	}
+
+	_, n := g.Dims()
+	basis, pivots := systematic(packRows(g), n, identityPerm(n))
+	k := len(basis)
+	if k == 0 {
+		return 0
+	}
+
+	used := make([]bool, n)
+	for _, p := range pivots {
+		used[p] = true
+	}
+	gammas := [][][]uint64{basis}
+	ranks := []int{k}
+	for {
+		order := make([]int, 0, n)
+		for j := 0; j < n; j++ {
+			if !used[j] {
+				order = append(order, j)
+			}
+		}
+		if len(order) == 0 {
+			break
+		}
+		for j := 0; j < n; j++ {
+			if used[j] {
+				order = append(order, j)
+			}
+		}
+
+		gamma, ps := systematic(basis, n, order)
+		rank := 0
+		for _, p := range ps {
+			if !used[p] {
+				rank++
+				used[p] = true
+			}
+		}
+		if rank == 0 {
+			break
+		}
+		gammas = append(gammas, gamma)
+		ranks = append(ranks, rank)
+	}
+
+	upper := n + 1
+	for w := 1; w <= k; w++ {
+		for _, gamma := range gammas {
+			combinations(gamma, w, func(codeword []uint64) {
+				if wt := weight(codeword); wt > 0 && wt < upper {
+					upper = wt
+				}
+			})
+		}
+
+		lower := 0
+		for _, r := range ranks {
+			if b := w + 1 - (k - r); b > 0 {
+				lower += b
+			}
+		}
+		if lower >= upper {
+			break
+		}
+	}
+	return upper
+}
+
+// EstimateMinDistance returns an upper bound on the minimum Hamming distance of the code spanned
+// by the rows of the generator matrix g (taken mod 2) using Stern's information set decoding
+// algorithm with p = 2. Each of the iterations brings g into systematic form on a random
+// information set, splits the rows into two random halves and picks l random redundancy positions.
+// Every sum of at most p rows of one half is combined with every such sum of the other half that
+// agrees with it on those l positions, l is about the logarithm of the number of sums so each sum
+// meets few others. Low weight codewords are found with high probability once enough iterations
+// are used, making this suitable for codes too large for MinDistance. It returns 0 when the code
+// has no nonzero codewords.
+//
+// Like MinDistance it takes a generator matrix, use Code.EstimateMinDistance for a code given by
+// a parity-check matrix.
+func EstimateMinDistance(rng *rand.Rand, g *intmat.Matrix, iterations int) int {
+	if rng == nil || g == nil {
+		panic("estimate min distance input was found to be nil")
+	}
+	if iterations <= 0 {
+		panic("iterations must be positive")
+	}
+
+	const p = 2
+	_, n := g.Dims()
+	basis, _ := systematic(packRows(g), n, identityPerm(n))
+	k := len(basis)
+	if k == 0 {
+		return 0
+	}
+
+	half := k / 2
+	sums := half + half*(half-1)/2
+	l := bits.Len(uint(sums))
+	if l > n-k {
+		l = n - k
+	}
+
+	upper := n + 1
+	check := func(codeword []uint64) {
+		if wt := weight(codeword); wt > 0 && wt < upper {
+			upper = wt
+		}
+	}
+	for it := 0; it < iterations; it++ {
+		gamma, pivots := systematic(basis, n, rng.Perm(n))
+
+		isPivot := make([]bool, n)
+		for _, j := range pivots {
+			isPivot[j] = true
+		}
+		var window []int
+		for _, j := range rng.Perm(n) {
+			if len(window) == l {
+				break
+			}
+			if !isPivot[j] {
+				window = append(window, j)
+			}
+		}
+		key := func(codeword []uint64) uint64 {
+			var projection uint64
+			for b, j := range window {
+				if bit(codeword, j) {
+					projection |= 1 << uint(b)
+				}
+			}
+			return projection
+		}
+
+		order := rng.Perm(k)
+		left := make([][]uint64, half)
+		right := make([][]uint64, k-half)
+		for i, r := range order {
+			if i < half {
+				left[i] = gamma[r]
+			} else {
+				right[i-half] = gamma[r]
+			}
+		}
+
+		leftSums := map[uint64][][]uint64{}
+		for w := 1; w <= p; w++ {
+			combinations(left, w, func(codeword []uint64) {
+				check(codeword)
+				projection := key(codeword)
+				leftSums[projection] = append(leftSums[projection], append([]uint64(nil), codeword...))
+			})
+		}
+
+		sum := make([]uint64, words(n))
+		for w := 1; w <= p; w++ {
+			combinations(right, w, func(codeword []uint64) {
+				check(codeword)
+				for _, other := range leftSums[key(codeword)] {
+					copy(sum, codeword)
+					xor(sum, other)
+					check(sum)
+				}
+			})
+		}
+	}
+	return upper
+}
+
+// WeightDistribution returns the number of codewords of each Hamming weight 0..n, see the
+// function WeightDistribution. The weights do not depend on the column order of G.
+func (code *Code) WeightDistribution() []int {
+	return WeightDistribution(code.G)
+}
+
+// MinDistance returns the minimum Hamming distance of the code, see the function MinDistance.
+func (code *Code) MinDistance() int {
+	return MinDistance(code.G)
+}
+
+// EstimateMinDistance returns an upper bound on the minimum Hamming distance of the code, see the
+// function EstimateMinDistance.
+func (code *Code) EstimateMinDistance(rng *rand.Rand, iterations int) int {
+	return EstimateMinDistance(rng, code.G, iterations)
+}
+
+// systematic runs Gauss-Jordan elimination over GF(2) on copies of rows, choosing pivot columns
+// in the given order. It returns the nonzero reduced rows and their pivot columns.
+func systematic(rows [][]uint64, n int, order []int) ([][]uint64, []int) {
+	reduced := make([][]uint64, len(rows))
+	for i, row := range rows {
+		reduced[i] = append([]uint64(nil), row...)
+	}
+
+	var pivots []int
+	r := 0
+	for _, j := range order {
+		if r == len(reduced) {
+			break
+		}
+		p := -1
+		for i := r; i < len(reduced); i++ {
+			if bit(reduced[i], j) {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		reduced[r], reduced[p] = reduced[p], reduced[r]
+		for i := range reduced {
+			if i != r && bit(reduced[i], j) {
+				xor(reduced[i], reduced[r])
+			}
+		}
+		pivots = append(pivots, j)
+		r++
+	}
+	return reduced[:r], pivots
+}
+
+// combinations calls fn with the sum of every set of w rows.
+func combinations(rows [][]uint64, w int, fn func(codeword []uint64)) {
+	if w <= 0 || w > len(rows) {
+		return
+	}
+
+	sums := make([][]uint64, w+1)
+	for d := range sums {
+		sums[d] = make([]uint64, len(rows[0]))
+	}
+
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == w {
+			fn(sums[depth])
+			return
+		}
+		for i := start; i <= len(rows)-(w-depth); i++ {
+			copy(sums[depth+1], sums[depth])
+			xor(sums[depth+1], rows[i])
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+}
+
+// packRows returns the rows of m, taken mod 2, packed 64 columns to a word.
+func packRows(m *intmat.Matrix) [][]uint64 {
+	rows, cols := m.Dims()
+	packed := make([][]uint64, rows)
+	for i := range packed {
+		packed[i] = make([]uint64, words(cols))
+	}
+	m.DoNonzero(func(i, j, value int) {
+		if value&1 == 1 {
+			packed[i][j/64] |= 1 << uint(j%64)
+		}
+	})
+	return packed
+}
+
+func words(n int) int {
+	return (n + 63) / 64
+}
+
+func bit(row []uint64, j int) bool {
+	return row[j/64]>>uint(j%64)&1 == 1
+}
+
+func xor(dst, src []uint64) {
+	for w := range dst {
+		dst[w] ^= src[w]
+	}
+}
+
+func weight(row []uint64) int {
+	count := 0
+	for _, w := range row {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
@@ -0,0 +1,136 @@
+package ldpc
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/nathanhack/intmat"
+)
+
+// golay24 returns a generator of the extended binary Golay code, built from the cyclic
+// (23,12) Golay code with generator polynomial x^11+x^10+x^6+x^5+x^4+x^2+1 plus a parity bit.
+func golay24() *intmat.Matrix {
+	poly := []int{1, 0, 1, 0, 1, 1, 1, 0, 0, 0, 1, 1}
+	g := intmat.NewMat(12, 24)
+	for i := 0; i < 12; i++ {
+		for d, c := range poly {
+			if c == 1 {
+				g.Set(i, i+d, 1)
+			}
+		}
+		// every row has weight 7 so the parity bit is always set
+		g.Set(i, 23, 1)
+	}
+	return g
+}
+
+func TestWeightDistribution(t *testing.T) {
+	tests := []struct {
+		g        *intmat.Matrix
+		expected []int
+	}{
+		{NewCode(hamming74()).G, []int{1, 0, 0, 7, 7, 0, 0, 1}},
+		{intmat.NewMat(2, 5, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1), []int{1, 0, 0, 0, 0, 1}},
+		{intmat.NewMat(1, 3), []int{1, 0, 0, 0}},
+		{golay24(), []int{1, 0, 0, 0, 0, 0, 0, 0, 759, 0, 0, 0, 2576, 0, 0, 0, 759, 0, 0, 0, 0, 0, 0, 0, 1}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := WeightDistribution(test.g)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMinDistance(t *testing.T) {
+	tests := []struct {
+		g        *intmat.Matrix
+		expected int
+	}{
+		{NewCode(hamming74()).G, 3},
+		{intmat.NewMat(2, 5, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1), 5},
+		{intmat.NewMat(1, 3), 0},
+		{intmat.Identity(4), 1},
+		{golay24(), 8},
+		{golay24().Slice(0, 0, 12, 23), 7},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := MinDistance(test.g)
+			if actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMinDistance_WeightDistribution(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for i := 0; i < 20; i++ {
+		g := Bernoulli(rng, 6, 14, 0.4)
+
+		expected := 0
+		for w, count := range WeightDistribution(g) {
+			if w > 0 && count > 0 {
+				expected = w
+				break
+			}
+		}
+
+		actual := MinDistance(g)
+		if actual != expected {
+			t.Fatalf("expected %v but found %v for \n%v", expected, actual, g)
+		}
+	}
+}
+
+func TestEstimateMinDistance(t *testing.T) {
+	tests := []struct {
+		g        *intmat.Matrix
+		expected int
+	}{
+		{NewCode(hamming74()).G, 3},
+		{intmat.NewMat(1, 3), 0},
+		{golay24(), 8},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := EstimateMinDistance(rand.New(rand.NewSource(1)), test.g, 50)
+			if actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEstimateMinDistance_MinDistance(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for i := 0; i < 20; i++ {
+		g := Bernoulli(rng, 16, 40, 0.3)
+
+		expected := MinDistance(g)
+		actual := EstimateMinDistance(rand.New(rand.NewSource(int64(i))), g, 30)
+		if actual != expected {
+			t.Fatalf("expected %v but found %v for \n%v", expected, actual, g)
+		}
+	}
+}
+
+func TestCode_Distance(t *testing.T) {
+	code := NewCode(hamming74())
+
+	expected := []int{1, 0, 0, 7, 7, 0, 0, 1}
+	if actual := code.WeightDistribution(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+	if actual := code.MinDistance(); actual != 3 {
+		t.Fatalf("expected %v but found %v", 3, actual)
+	}
+	if actual := code.EstimateMinDistance(rand.New(rand.NewSource(1)), 10); actual != 3 {
+		t.Fatalf("expected %v but found %v", 3, actual)
+	}
+}
@@ -0,0 +1,254 @@
+package ldpc
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/nathanhack/intmat"
+)
+
+// Gallager creates a random (wc,wr)-regular parity-check matrix with n columns using Gallager's
+// construction. The matrix is made of wc bands of n/wr rows, the first band holds consecutive
+// runs of wr ones and every other band is a random column permutation of the first.
+// n must be a multiple of wr.
+func Gallager(rng *rand.Rand, n, wc, wr int) *intmat.Matrix {
+	if rng == nil {
+		panic("random source was found to be nil")
+	}
+	if n <= 0 || wc <= 0 || wr <= 0 {
+		panic("n, wc and wr must be positive")
+	}
+	if n%wr != 0 {
+		panic(fmt.Sprintf("n (%v) must be a multiple of wr (%v)", n, wr))
+	}
+
+	band := n / wr
+	h := intmat.NewMat(wc*band, n)
+	for b := 0; b < wc; b++ {
+		perm := identityPerm(n)
+		if b > 0 {
+			perm = rng.Perm(n)
+		}
+		for j := 0; j < n; j++ {
+			h.Set(b*band+j/wr, perm[j], 1)
+		}
+	}
+	return h
+}
+
+// MacKayNeal creates a random mxn parity-check matrix with column weight wc using MacKay and
+// Neal's construction. Columns are added one at a time, drawing their rows from the rows with
+// the lowest weight so far so row weights stay as uniform as possible, and rejecting columns
+// that overlap a previous column in more than one row (a 4-cycle). When no such column is
+// found within a bounded number of draws the best draw is used.
+func MacKayNeal(rng *rand.Rand, m, n, wc int) *intmat.Matrix {
+	if rng == nil {
+		panic("random source was found to be nil")
+	}
+	if m <= 0 || n <= 0 || wc <= 0 {
+		panic("m, n and wc must be positive")
+	}
+	if wc > m {
+		panic(fmt.Sprintf("column weight (%v) must not exceed the number of rows (%v)", wc, m))
+	}
+
+	const attempts = 100
+
+	h := intmat.NewMat(m, n)
+	rowWeights := make([]int, m)
+	rowCols := make([][]int, m)
+	for j := 0; j < n; j++ {
+		var best []int
+		bestOverlap := -1
+		for a := 0; a < attempts; a++ {
+			rows := lightestRows(rng, rowWeights, wc, float64(a)/10)
+			overlap := maxOverlap(rows, rowCols)
+			if bestOverlap < 0 || overlap < bestOverlap {
+				best, bestOverlap = rows, overlap
+			}
+			if overlap <= 1 {
+				break
+			}
+		}
+
+		for _, i := range best {
+			h.Set(i, j, 1)
+			rowWeights[i]++
+			rowCols[i] = append(rowCols[i], j)
+		}
+	}
+	return h
+}
+
+// PEG creates an m row parity-check matrix using the progressive edge growth algorithm of
+// Hu, Eleftheriou and Arnold. varDegrees holds the degree of each variable node (column), and
+// edges are placed one at a time on the check that is farthest from the variable in the graph
+// built so far, breaking ties by lowest check degree and then at random. Variables are
+// processed in the given order, listing them by increasing degree gives the best results.
+func PEG(rng *rand.Rand, m int, varDegrees []int) *intmat.Matrix {
+	if rng == nil {
+		panic("random source was found to be nil")
+	}
+	if m <= 0 {
+		panic("m must be positive")
+	}
+	for j, d := range varDegrees {
+		if d < 0 || d > m {
+			panic(fmt.Sprintf("variable %v degree (%v) must be in [0,%v]", j, d, m))
+		}
+	}
+
+	n := len(varDegrees)
+	checks := make([][]int, m)
+	vars := make([][]int, n)
+	for j, d := range varDegrees {
+		for k := 0; k < d; k++ {
+			var candidates []int
+			if k == 0 {
+				candidates = identityPerm(m)
+			} else {
+				candidates = farthestChecks(j, checks, vars)
+			}
+
+			c := lowestDegree(rng, candidates, checks)
+			checks[c] = append(checks[c], j)
+			vars[j] = append(vars[j], c)
+		}
+	}
+
+	h := intmat.NewMat(m, n)
+	for j, cs := range vars {
+		for _, i := range cs {
+			h.Set(i, j, 1)
+		}
+	}
+	return h
+}
+
+// Bernoulli creates a rows x cols matrix where each entry is independently 1 with probability p.
+func Bernoulli(rng *rand.Rand, rows, cols int, p float64) *intmat.Matrix {
+	if rng == nil {
+		panic("random source was found to be nil")
+	}
+	if p < 0 || p > 1 {
+		panic(fmt.Sprintf("probability (%v) must be in [0,1]", p))
+	}
+
+	h := intmat.NewMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if rng.Float64() < p {
+				h.Set(i, j, 1)
+			}
+		}
+	}
+	return h
+}
+
+// farthestChecks expands the graph breadth first from variable j and returns the checks that
+// are either unreachable or, when every check is reachable, reached last.
+func farthestChecks(j int, checks, vars [][]int) []int {
+	reached := make([]bool, len(checks))
+	visited := make([]bool, len(vars))
+	visited[j] = true
+	count := 0
+	for _, c := range vars[j] {
+		if !reached[c] {
+			reached[c] = true
+			count++
+		}
+	}
+
+	frontier := vars[j]
+	for {
+		var next []int
+		for _, c := range frontier {
+			for _, v := range checks[c] {
+				if visited[v] {
+					continue
+				}
+				visited[v] = true
+				for _, c2 := range vars[v] {
+					if !reached[c2] {
+						reached[c2] = true
+						next = append(next, c2)
+					}
+				}
+			}
+		}
+
+		if len(next) == 0 {
+			unreached := make([]int, 0, len(checks)-count)
+			for c, r := range reached {
+				if !r {
+					unreached = append(unreached, c)
+				}
+			}
+			return unreached
+		}
+
+		count += len(next)
+		if count == len(checks) {
+			return next
+		}
+		frontier = next
+	}
+}
+
+// lowestDegree picks, uniformly at random, one of the candidate checks with the lowest degree.
+func lowestDegree(rng *rand.Rand, candidates []int, checks [][]int) int {
+	var best []int
+	for _, c := range candidates {
+		switch {
+		case len(best) == 0 || len(checks[c]) < len(checks[best[0]]):
+			best = []int{c}
+		case len(checks[c]) == len(checks[best[0]]):
+			best = append(best, c)
+		}
+	}
+	return best[rng.Intn(len(best))]
+}
+
+// lightestRows draws w distinct rows, preferring rows with the lowest weight and breaking ties at random.
+// Each row's weight is perturbed by a random amount in [0,slack) so that larger slack lets slightly
+// heavier rows be drawn.
+func lightestRows(rng *rand.Rand, rowWeights []int, w int, slack float64) []int {
+	keys := make([]float64, len(rowWeights))
+	order := make([]int, len(rowWeights))
+	for i, weight := range rowWeights {
+		keys[i] = float64(weight) + slack*rng.Float64()
+		order[i] = i
+	}
+	rng.Shuffle(len(order), func(a, b int) {
+		order[a], order[b] = order[b], order[a]
+	})
+	// a stable sort keeps the random order among rows of equal weight
+	sort.SliceStable(order, func(a, b int) bool {
+		return keys[order[a]] < keys[order[b]]
+	})
+	return order[:w]
+}
+
+// maxOverlap returns the largest number of rows shared between the candidate column and any existing column.
+func maxOverlap(rows []int, rowCols [][]int) int {
+	shared := map[int]int{}
+	most := 0
+	for _, i := range rows {
+		for _, c := range rowCols[i] {
+			shared[c]++
+			if shared[c] > most {
+				most = shared[c]
+			}
+		}
+	}
+	return most
+}
+
+func identityPerm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
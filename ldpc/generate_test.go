@@ -0,0 +1,190 @@
+package ldpc
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/nathanhack/intmat"
+)
+
+func weights(h *intmat.Matrix) (rowWeights, colWeights []int) {
+	rows, cols := h.Dims()
+	rowWeights = make([]int, rows)
+	colWeights = make([]int, cols)
+	h.DoNonzero(func(i, j, value int) {
+		rowWeights[i]++
+		colWeights[j]++
+	})
+	return
+}
+
+// hasFourCycle reports whether two columns of h share more than one row.
+func hasFourCycle(h *intmat.Matrix) bool {
+	_, vars := tanner(h)
+	for a := range vars {
+		for b := a + 1; b < len(vars); b++ {
+			shared := 0
+			for _, i := range vars[a] {
+				for _, k := range vars[b] {
+					if i == k {
+						shared++
+					}
+				}
+			}
+			if shared > 1 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestGallager(t *testing.T) {
+	tests := []struct {
+		n, wc, wr int
+	}{
+		{12, 3, 4},
+		{20, 3, 5},
+		{8, 1, 8},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			h := Gallager(rand.New(rand.NewSource(1)), test.n, test.wc, test.wr)
+			rows, cols := h.Dims()
+			if rows != test.n*test.wc/test.wr || cols != test.n {
+				t.Fatalf("expected shape (%v,%v) but found (%v,%v)", test.n*test.wc/test.wr, test.n, rows, cols)
+			}
+
+			rowWeights, colWeights := weights(h)
+			for _, w := range rowWeights {
+				if w != test.wr {
+					t.Fatalf("expected row weights %v but found %v", test.wr, rowWeights)
+				}
+			}
+			for _, w := range colWeights {
+				if w != test.wc {
+					t.Fatalf("expected column weights %v but found %v", test.wc, colWeights)
+				}
+			}
+
+			again := Gallager(rand.New(rand.NewSource(1)), test.n, test.wc, test.wr)
+			if !h.Equals(again) {
+				t.Fatalf("expected the same seed to give the same matrix")
+			}
+		})
+	}
+}
+
+func TestMacKayNeal(t *testing.T) {
+	h := MacKayNeal(rand.New(rand.NewSource(1)), 20, 40, 3)
+
+	rowWeights, colWeights := weights(h)
+	for _, w := range colWeights {
+		if w != 3 {
+			t.Fatalf("expected column weights 3 but found %v", colWeights)
+		}
+	}
+	for _, w := range rowWeights {
+		if w < 5 || w > 7 {
+			t.Fatalf("expected row weights between 5 and 7 but found %v", rowWeights)
+		}
+	}
+	if hasFourCycle(h) {
+		t.Fatalf("expected no 4-cycles in \n%v", h)
+	}
+}
+
+func TestPEG(t *testing.T) {
+	degrees := make([]int, 24)
+	for j := range degrees {
+		degrees[j] = 2
+		if j >= 12 {
+			degrees[j] = 3
+		}
+	}
+
+	h := PEG(rand.New(rand.NewSource(1)), 12, degrees)
+	rows, cols := h.Dims()
+	if rows != 12 || cols != 24 {
+		t.Fatalf("expected shape (12,24) but found (%v,%v)", rows, cols)
+	}
+
+	rowWeights, colWeights := weights(h)
+	for j, w := range colWeights {
+		if w != degrees[j] {
+			t.Fatalf("expected column weights %v but found %v", degrees, colWeights)
+		}
+	}
+	for _, w := range rowWeights {
+		if w < 4 || w > 7 {
+			t.Fatalf("expected row weights between 4 and 7 but found %v", rowWeights)
+		}
+	}
+	if hasFourCycle(h) {
+		t.Fatalf("expected no 4-cycles in \n%v", h)
+	}
+}
+
+func TestBernoulli(t *testing.T) {
+	tests := []struct {
+		p             float64
+		expectedCount int
+	}{
+		{0, 0},
+		{1, 100 * 50},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			h := Bernoulli(rand.New(rand.NewSource(1)), 100, 50, test.p)
+			count := 0
+			h.DoNonzero(func(i, j, value int) {
+				count++
+			})
+			if count != test.expectedCount {
+				t.Fatalf("expected %v ones but found %v", test.expectedCount, count)
+			}
+		})
+	}
+
+	h := Bernoulli(rand.New(rand.NewSource(1)), 100, 50, 0.1)
+	count := 0
+	h.DoNonzero(func(i, j, value int) {
+		count++
+	})
+	if count < 400 || count > 600 {
+		t.Fatalf("expected about 500 ones but found %v", count)
+	}
+	if !h.Equals(Bernoulli(rand.New(rand.NewSource(1)), 100, 50, 0.1)) {
+		t.Fatalf("expected the same seed to give the same matrix")
+	}
+}
+
+func TestSumProduct_Gallager(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	h := Gallager(rng, 60, 3, 6)
+	code := NewCode(h)
+
+	msg := intmat.NewVec(code.K)
+	for t := 0; t < code.K; t++ {
+		msg.Set(t, rng.Intn(2))
+	}
+	codeword := code.Encode(msg)
+
+	llr := make([]float64, code.N)
+	for j := range llr {
+		llr[j] = 3
+		if codeword.At(j) == 1 {
+			llr[j] = -3
+		}
+	}
+	// a few weak, wrong decisions
+	for _, j := range []int{4, 31, 50} {
+		llr[j] = -llr[j] / 6
+	}
+
+	actual := SumProduct(h, llr, 50)
+	if !actual.Converged || !actual.Codeword.Equals(codeword) {
+		t.Fatalf("expected %v but found %v", codeword, actual.Codeword)
+	}
+}
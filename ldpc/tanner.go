@@ -0,0 +1,22 @@
+package ldpc
+
+import (
+	"github.com/nathanhack/intmat"
+)
+
+// tanner returns the adjacency lists of the Tanner graph of h. checks[i] holds the variable
+// nodes taking part in check i and vars[j] holds the checks variable j takes part in.
+// Values of h are taken mod 2 and every list is in increasing order.
+func tanner(h *intmat.Matrix) (checks, vars [][]int) {
+	rows, cols := h.Dims()
+	checks = make([][]int, rows)
+	vars = make([][]int, cols)
+	h.DoNonzero(func(i, j, value int) {
+		if value&1 == 0 {
+			return
+		}
+		checks[i] = append(checks[i], j)
+		vars[j] = append(vars[j], i)
+	})
+	return
+}
@@ -3,19 +3,91 @@ package intmat
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
+	"math/bits"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/olekukonko/tablewriter"
 )
 
 type Matrix struct {
+	store      *matStore // shared by this matrix and all of its views
+	transposed bool      // the rows of this matrix are the columns of store
+	rows       int       // total number rows available to this matrix
+	rowStart   int       // [rowStart,rowEnd)
+	cols       int       // total number cols available to this matrix
+	colStart   int       // [colStart,colEnd)
+
+}
+
+// matStore holds the values of a matrix and all of its views. A Snapshot shares the maps of its
+// store until one of the stores sharing them is written to, that store then copies the maps first.
+type matStore struct {
 	rowValues map[int]map[int]int //hold rowValues for (X,Y)
 	colValues map[int]map[int]int //easy access to (Y,X)
-	rows      int                 // total number rows available to this matrix
-	rowStart  int                 // [rowStart,rowEnd)
-	cols      int                 // total number cols available to this matrix
-	colStart  int                 // [colStart,colEnd)
+	shared    *int32              // number of stores sharing the maps
+}
+
+func newMatStore() *matStore {
+	return &matStore{
+		rowValues: map[int]map[int]int{},
+		colValues: map[int]map[int]int{},
+		shared:    newShareCount(),
+	}
+}
+
+// own makes sure the store has maps of its own before they are written to.
+func (s *matStore) own() {
+	if atomic.LoadInt32(s.shared) == 1 {
+		return
+	}
+
+	rowValues := make(map[int]map[int]int, len(s.rowValues))
+	colValues := make(map[int]map[int]int, len(s.colValues))
+	for r, cs := range s.rowValues {
+		for c, v := range cs {
+			if rowValues[r] == nil {
+				rowValues[r] = make(map[int]int, len(cs))
+			}
+			rowValues[r][c] = v
+			if colValues[c] == nil {
+				colValues[c] = make(map[int]int, len(s.colValues[c]))
+			}
+			colValues[c][r] = v
+		}
+	}
+	atomic.AddInt32(s.shared, -1)
+	s.rowValues, s.colValues, s.shared = rowValues, colValues, newShareCount()
+}
 
+// newShareCount returns the share count of a store that is the only one using its maps.
+func newShareCount() *int32 {
+	count := int32(1)
+	return &count
+}
+
+func (mat *Matrix) rowValues() map[int]map[int]int {
+	if mat.store == nil {
+		return nil
+	}
+	if mat.transposed {
+		return mat.store.colValues
+	}
+	return mat.store.rowValues
+}
+
+func (mat *Matrix) colValues() map[int]map[int]int {
+	if mat.store == nil {
+		return nil
+	}
+	if mat.transposed {
+		return mat.store.rowValues
+	}
+	return mat.store.colValues
 }
 
 type matrix struct {
@@ -30,8 +102,8 @@ type matrix struct {
 
 func (mat *Matrix) MarshalJSON() ([]byte, error) {
 	return json.Marshal(matrix{
-		RowValues: mat.rowValues,
-		ColValues: mat.colValues,
+		RowValues: mat.rowValues(),
+		ColValues: mat.colValues(),
 		Rows:      mat.rows,
 		RowStart:  mat.rowStart,
 		Cols:      mat.cols,
@@ -45,8 +117,8 @@ func (mat *Matrix) UnmarshalJSON(bytes []byte) error {
 	if err != nil {
 		return err
 	}
-	mat.rowValues = m.RowValues
-	mat.colValues = m.ColValues
+	mat.store = &matStore{rowValues: m.RowValues, colValues: m.ColValues, shared: newShareCount()}
+	mat.transposed = false
 	mat.rows = m.Rows
 	mat.rowStart = m.RowStart
 	mat.cols = m.Cols
@@ -64,12 +136,11 @@ func NewMat(rows, cols int, values ...int) *Matrix {
 	}
 
 	mat := Matrix{
-		rowValues: map[int]map[int]int{},
-		colValues: map[int]map[int]int{},
-		rows:      rows,
-		rowStart:  0,
-		cols:      cols,
-		colStart:  0,
+		store:    newMatStore(),
+		rows:     rows,
+		rowStart: 0,
+		cols:     cols,
+		colStart: 0,
 	}
 
 	if len(values) > 0 {
@@ -91,12 +162,11 @@ func NewMatFromVec(vec *Vector) *Matrix {
 // Identity create an identity matrix (one's on the diagonal).
 func Identity(size int) *Matrix {
 	mat := Matrix{
-		rowValues: map[int]map[int]int{},
-		colValues: map[int]map[int]int{},
-		rows:      size,
-		rowStart:  0,
-		cols:      size,
-		colStart:  0,
+		store:    newMatStore(),
+		rows:     size,
+		rowStart: 0,
+		cols:     size,
+		colStart: 0,
 	}
 
 	for i := 0; i < size; i++ {
@@ -106,15 +176,81 @@ func Identity(size int) *Matrix {
 	return &mat
 }
 
+// Circulant creates a square circulant matrix whose first row is first, each following row is
+// the row above it cyclically shifted one position to the right.
+func Circulant(first *Vector) *Matrix {
+	if first == nil {
+		panic("circulant input was found to be nil")
+	}
+
+	size := first.Len()
+	mat := NewMat(size, size)
+	js, values := first.SortedNonzeroValues()
+	for i := 0; i < size; i++ {
+		for k, j := range js {
+			mat.set(i, (j+i)%size, values[k])
+		}
+	}
+
+	return mat
+}
+
+// CirculantPermutation creates a size x size circulant permutation matrix, the identity matrix
+// with its columns cyclically shifted right by shift. Negative shifts shift to the left.
+func CirculantPermutation(size, shift int) *Matrix {
+	mat := NewMat(size, size)
+	if size == 0 {
+		return mat
+	}
+
+	shift %= size
+	if shift < 0 {
+		shift += size
+	}
+	for i := 0; i < size; i++ {
+		mat.set(i, (i+shift)%size, 1)
+	}
+
+	return mat
+}
+
+// QuasiCyclic lifts the exponent (base) matrix into a quasi-cyclic matrix made of z x z blocks, as
+// used to describe the 802.11n and 5G NR LDPC codes. An entry e >= 0 becomes the circulant
+// permutation matrix with shift e mod z and a negative entry becomes the zero block.
+func QuasiCyclic(base [][]int, z int) *Matrix {
+	if z <= 0 {
+		panic("lifting size must be positive")
+	}
+
+	cols := 0
+	if len(base) > 0 {
+		cols = len(base[0])
+	}
+
+	mat := NewMat(len(base)*z, cols*z)
+	for bi, row := range base {
+		if len(row) != cols {
+			panic(fmt.Sprintf("base matrix rows must be the same length, row %v has %v entries expected %v", bi, len(row), cols))
+		}
+		for bj, e := range row {
+			if e < 0 {
+				continue
+			}
+			mat.SetMatrix(CirculantPermutation(z, e), bi*z, bj*z)
+		}
+	}
+
+	return mat
+}
+
 // Copy will create a NEW matrix that will have all the same values as m.
 func Copy(m *Matrix) *Matrix {
 	mat := Matrix{
-		rowValues: make(map[int]map[int]int),
-		colValues: make(map[int]map[int]int),
-		rows:      m.rows,
-		rowStart:  0,
-		cols:      m.cols,
-		colStart:  0,
+		store:    newMatStore(),
+		rows:     m.rows,
+		rowStart: 0,
+		cols:     m.cols,
+		colStart: 0,
 	}
 
 	for i := 0; i < mat.rows; i++ {
@@ -126,6 +262,148 @@ func Copy(m *Matrix) *Matrix {
 	return &mat
 }
 
+// Kron returns the Kronecker product of a and b, the block matrix made of b scaled by each value of a.
+func Kron(a, b *Matrix) *Matrix {
+	if a == nil || b == nil {
+		panic("kronecker product input was found to be nil")
+	}
+
+	mat := NewMat(a.rows*b.rows, a.cols*b.cols)
+	a.DoNonzero(func(i, j, v1 int) {
+		b.DoNonzero(func(k, l, v2 int) {
+			mat.set(i*b.rows+k, j*b.cols+l, v1*v2)
+		})
+	})
+
+	return mat
+}
+
+// DirectSum returns the block diagonal matrix with ms placed along the diagonal.
+func DirectSum(ms ...*Matrix) *Matrix {
+	rows, cols := 0, 0
+	for _, m := range ms {
+		if m == nil {
+			panic("direct sum input was found to be nil")
+		}
+		rows += m.rows
+		cols += m.cols
+	}
+
+	mat := NewMat(rows, cols)
+	i, j := 0, 0
+	for _, m := range ms {
+		mat.setMatrix(m, i, j)
+		i += m.rows
+		j += m.cols
+	}
+
+	return mat
+}
+
+// HStack returns the matrices placed side by side, they must all have the same number of rows.
+func HStack(ms ...*Matrix) *Matrix {
+	return Block([][]*Matrix{ms})
+}
+
+// VStack returns the matrices placed one above the other, they must all have the same number of columns.
+func VStack(ms ...*Matrix) *Matrix {
+	blocks := make([][]*Matrix, len(ms))
+	for i, m := range ms {
+		blocks[i] = []*Matrix{m}
+	}
+	return Block(blocks)
+}
+
+// Block assembles a matrix from a grid of blocks. Blocks in the same block row must have the same
+// number of rows and blocks in the same block column the same number of columns. A nil block is a
+// zero block whose shape is taken from the other blocks in its block row and block column.
+func Block(blocks [][]*Matrix) *Matrix {
+	heights, widths := blockShape(len(blocks), func(bi int) int { return len(blocks[bi]) }, func(bi, bj int) (int, int, bool) {
+		m := blocks[bi][bj]
+		if m == nil {
+			return 0, 0, false
+		}
+		return m.rows, m.cols, true
+	})
+
+	rows, cols := 0, 0
+	for _, h := range heights {
+		rows += h
+	}
+	for _, w := range widths {
+		cols += w
+	}
+
+	mat := NewMat(rows, cols)
+	i := 0
+	for bi, blockRow := range blocks {
+		j := 0
+		for bj, m := range blockRow {
+			if m != nil {
+				mat.setMatrix(m, i, j)
+			}
+			j += widths[bj]
+		}
+		i += heights[bi]
+	}
+
+	return mat
+}
+
+// blockShape works out the height of each block row and the width of each block column of a grid
+// of blocks, shape reports the dimensions of a block or false for a nil block.
+func blockShape(blockRows int, blockCols func(bi int) int, shape func(bi, bj int) (int, int, bool)) (heights, widths []int) {
+	if blockRows == 0 {
+		panic("block matrix must have at least one block row")
+	}
+
+	n := blockCols(0)
+	if n == 0 {
+		panic("block matrix must have at least one block column")
+	}
+
+	heights = make([]int, blockRows)
+	widths = make([]int, n)
+	for bi := range heights {
+		heights[bi] = -1
+	}
+	for bj := range widths {
+		widths[bj] = -1
+	}
+
+	for bi := 0; bi < blockRows; bi++ {
+		if blockCols(bi) != n {
+			panic(fmt.Sprintf("block row %v has %v blocks expected %v", bi, blockCols(bi), n))
+		}
+		for bj := 0; bj < n; bj++ {
+			rows, cols, ok := shape(bi, bj)
+			if !ok {
+				continue
+			}
+			if heights[bi] >= 0 && heights[bi] != rows {
+				panic(fmt.Sprintf("block (%v,%v) has %v rows expected %v", bi, bj, rows, heights[bi]))
+			}
+			if widths[bj] >= 0 && widths[bj] != cols {
+				panic(fmt.Sprintf("block (%v,%v) has %v cols expected %v", bi, bj, cols, widths[bj]))
+			}
+			heights[bi] = rows
+			widths[bj] = cols
+		}
+	}
+
+	for bi, h := range heights {
+		if h < 0 {
+			panic(fmt.Sprintf("block row %v has no blocks to take its shape from", bi))
+		}
+	}
+	for bj, w := range widths {
+		if w < 0 {
+			panic(fmt.Sprintf("block column %v has no blocks to take its shape from", bj))
+		}
+	}
+	return
+}
+
 // Slice creates a slice of the matrix.  The slice will be connected to the original matrix, changes to one
 // causes changes in the other.
 func (mat *Matrix) Slice(i, j, rows, cols int) *Matrix {
@@ -149,12 +427,12 @@ func (mat *Matrix) Slice(i, j, rows, cols int) *Matrix {
 
 func (mat *Matrix) slice(r, c, rows, cols int) *Matrix {
 	return &Matrix{
-		rowValues: mat.rowValues,
-		rows:      rows,
-		rowStart:  r,
-		colValues: mat.colValues,
-		cols:      cols,
-		colStart:  c,
+		store:      mat.store,
+		transposed: mat.transposed,
+		rows:       rows,
+		rowStart:   r,
+		cols:       cols,
+		colStart:   c,
 	}
 }
 
@@ -186,7 +464,7 @@ func (mat *Matrix) At(i, j int) int {
 }
 
 func (mat *Matrix) at(r, c int) int {
-	ys, ok := mat.rowValues[r]
+	ys, ok := mat.rowValues()[r]
 	if !ok {
 		return 0
 	}
@@ -209,7 +487,7 @@ func (mat *Matrix) Set(i, j, value int) {
 
 func (mat *Matrix) set(r, c, value int) {
 	if value == 0 {
-		ys, ok := mat.rowValues[r]
+		ys, ok := mat.rowValues()[r]
 		if !ok {
 			return
 		}
@@ -219,30 +497,33 @@ func (mat *Matrix) set(r, c, value int) {
 			return
 		}
 
+		mat.store.own()
+		ys = mat.rowValues()[r]
 		delete(ys, c)
-		if len(mat.rowValues[r]) == 0 {
-			delete(mat.rowValues, r)
+		if len(mat.rowValues()[r]) == 0 {
+			delete(mat.rowValues(), r)
 		}
 
-		delete(mat.colValues[c], r)
-		if len(mat.colValues[c]) == 0 {
-			delete(mat.colValues, c)
+		delete(mat.colValues()[c], r)
+		if len(mat.colValues()[c]) == 0 {
+			delete(mat.colValues(), c)
 		}
 
 		return
 	}
 
-	ys, ok := mat.rowValues[r]
+	mat.store.own()
+	ys, ok := mat.rowValues()[r]
 	if !ok {
 		ys = make(map[int]int)
-		mat.rowValues[r] = ys
+		mat.rowValues()[r] = ys
 	}
 	ys[c] = value
 
-	xs, ok := mat.colValues[c]
+	xs, ok := mat.colValues()[c]
 	if !ok {
 		xs = make(map[int]int)
-		mat.colValues[c] = xs
+		mat.colValues()[c] = xs
 	}
 	xs[r] = value
 }
@@ -251,12 +532,37 @@ func (mat *Matrix) set(r, c, value int) {
 // is connected to matrix it is a transpose of, and changes made to one affect the other.
 func (mat *Matrix) T() *Matrix {
 	return &Matrix{
-		rowValues: mat.colValues,
-		rows:      mat.cols,
-		rowStart:  mat.colStart,
-		colValues: mat.rowValues,
-		cols:      mat.rows,
-		colStart:  mat.rowStart,
+		store:      mat.store,
+		transposed: !mat.transposed,
+		rows:       mat.cols,
+		rowStart:   mat.colStart,
+		cols:       mat.rows,
+		colStart:   mat.rowStart,
+	}
+}
+
+// Snapshot returns a matrix with the same values as this one that shares its storage until
+// either side is written to, the first write then copies the storage. Unlike Slice and T the
+// snapshot does not see later changes to this matrix and this matrix does not see changes to the
+// snapshot. Views of a snapshot made with Slice or T share its storage as usual. Taking a
+// snapshot only reads this matrix, so several goroutines may snapshot it at the same time.
+func (mat *Matrix) Snapshot() *Matrix {
+	if mat.store == nil {
+		return &Matrix{store: newMatStore(), rows: mat.rows, cols: mat.cols}
+	}
+	atomic.AddInt32(mat.store.shared, 1)
+
+	return &Matrix{
+		store: &matStore{
+			rowValues: mat.store.rowValues,
+			colValues: mat.store.colValues,
+			shared:    mat.store.shared,
+		},
+		transposed: mat.transposed,
+		rows:       mat.rows,
+		rowStart:   mat.rowStart,
+		cols:       mat.cols,
+		colStart:   mat.colStart,
 	}
 }
 
@@ -281,7 +587,7 @@ func (mat *Matrix) ZeroizeRange(i, j, rows, cols int) {
 }
 
 func (mat *Matrix) zeroize(r, c, rows, col int) {
-	for rv, cs := range mat.rowValues {
+	for rv, cs := range mat.rowValues() {
 		if rv < r || r+rows <= rv {
 			continue
 		}
@@ -300,29 +606,30 @@ func (mat *Matrix) zeroize(r, c, rows, col int) {
 // Pow raises the matrix to the power of k using exponentiation by squaring.
 // The matrix must be square.
 func (mat *Matrix) Pow(k int) *Matrix {
+	return mat.PowBig(big.NewInt(int64(k)))
+}
+
+// PowBig raises the matrix to the power of k using square-and-multiply, for exponents too large
+// for an int. The matrix must be square.
+func (mat *Matrix) PowBig(k *big.Int) *Matrix {
 	if mat.rows != mat.cols {
 		panic(fmt.Sprintf("matrix must be square to raise to a power, got %dx%d", mat.rows, mat.cols))
 	}
 
-	if k < 0 {
+	if k.Sign() < 0 {
 		panic("power k must be non-negative")
 	}
 
-	if k == 0 {
-		return Identity(mat.rows)
-	}
-
 	result := Identity(mat.rows)
-	currentPower := Copy(mat) // Use a copy to avoid modifying the original matrix if k=1
+	currentPower := mat // the original matrix is never written to
 
-	for k > 0 {
-		if k%2 == 1 {
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
 			temp := NewMat(mat.rows, mat.cols)
 			temp.Mul(result, currentPower)
 			result = temp
 		}
-		k /= 2
-		if k > 0 { // Avoid unnecessary multiplication if k becomes 0
+		if i+1 < k.BitLen() { // Avoid unnecessary multiplication after the last bit
 			temp := NewMat(mat.rows, mat.cols)
 			temp.Mul(currentPower, currentPower)
 			currentPower = temp
@@ -331,6 +638,85 @@ func (mat *Matrix) Pow(k int) *Matrix {
 	return result
 }
 
+// PowMod raises the matrix to the power of k modulo m using exponentiation by squaring, every
+// entry of the result is in [0,m). The matrix must be square, k non-negative and m positive. The
+// modulus is an int like the entries, any positive int works without overflow, use
+// BigIntMatrix.PowMod for larger moduli.
+func (mat *Matrix) PowMod(k *big.Int, m int) *Matrix {
+	if mat.rows != mat.cols {
+		panic(fmt.Sprintf("matrix must be square to raise to a power, got %dx%d", mat.rows, mat.cols))
+	}
+
+	if k.Sign() < 0 {
+		panic("power k must be non-negative")
+	}
+
+	if m <= 0 {
+		panic(fmt.Sprintf("modulus m must be positive, got %v", m))
+	}
+
+	result := Identity(mat.rows)
+	result.mod(m)
+	currentPower := Copy(mat)
+	currentPower.mod(m)
+
+	for i := 0; i < k.BitLen(); i++ {
+		if k.Bit(i) == 1 {
+			temp := NewMat(mat.rows, mat.cols)
+			temp.mulMod(result, currentPower, m)
+			result = temp
+		}
+		if i+1 < k.BitLen() {
+			temp := NewMat(mat.rows, mat.cols)
+			temp.mulMod(currentPower, currentPower, m)
+			currentPower = temp
+		}
+	}
+	return result
+}
+
+// mulMod stores a*b modulo m in this matrix, a and b must already be reduced into [0,m).
+func (mat *Matrix) mulMod(a, b *Matrix, m int) {
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+
+	bCols := sortedKeys(b.colValues(), b.colStart, b.cols)
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		cs := a.rowValues()[r]
+		i := r - a.rowStart
+
+		for _, c := range bCols {
+			rs := b.colValues()[c]
+			j := c - b.colStart
+			value := 0
+			for ics, v1 := range cs {
+				if ics < a.colStart || a.colStart+a.cols <= ics {
+					continue
+				}
+
+				v2, ok := rs[ics-a.colStart+b.rowStart]
+				if ok {
+					value = addMod(value, mulMod(v1, v2, m), m)
+				}
+			}
+
+			mat.set(i+mat.rowStart, j+mat.colStart, value)
+		}
+	}
+}
+
+// mod reduces every entry of this matrix into [0,m).
+func (mat *Matrix) mod(m int) {
+	for _, r := range sortedKeys(mat.rowValues(), mat.rowStart, mat.rows) {
+		for c, v := range mat.rowEntries(r) {
+			v %= m
+			if v < 0 {
+				v += m
+			}
+			mat.set(r, c, v)
+		}
+	}
+}
+
 // Mul multiplies two matrices and stores the values in this matrix.
 func (mat *Matrix) Mul(a, b *Matrix) {
 	if a == nil || b == nil {
@@ -359,32 +745,209 @@ func (mat *Matrix) mul(a, b *Matrix) {
 	//first we need to clear mat
 	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
 
-	for r, cs := range a.rowValues {
-		if r < a.rowStart || a.rowStart+a.rows <= r {
-			continue
+	acc := newAccumulator(b.cols)
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		i := r - a.rowStart
+		acc.addRowProduct(a, b, r)
+		acc.flush(func(j, value int) {
+			mat.set(i+mat.rowStart, j+mat.colStart, value)
+		})
+	}
+}
+
+// MulParallel multiplies two matrices and stores the values in this matrix like Mul, splitting
+// the rows of the result across workers goroutines. Each worker only reads a and b and collects
+// its rows locally, the rows are then written into this matrix from the calling goroutine.
+// A workers value less than 1 uses runtime.GOMAXPROCS(0).
+func (mat *Matrix) MulParallel(a, b *Matrix, workers int) {
+	if a == nil || b == nil {
+		panic("multiply input was found to be nil")
+	}
+
+	if mat == a || mat == b {
+		panic("multiply self assignment not allowed")
+	}
+
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("multiply shape misalignment can't multiply (%v,%v)x(%v,%v)", a.rows, a.cols, b.rows, b.cols))
+	}
+
+	if mat.rows != a.rows || mat.cols != b.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, b.cols))
+	}
+
+	aRows := sortedKeys(a.rowValues(), a.rowStart, a.rows)
+	type entry struct {
+		j, value int
+	}
+	results := make([][]entry, len(aRows))
+	parallelRows(len(aRows), workers, func(lo, hi int) {
+		acc := newAccumulator(b.cols)
+		for k := lo; k < hi; k++ {
+			acc.addRowProduct(a, b, aRows[k])
+			acc.flush(func(j, value int) {
+				results[k] = append(results[k], entry{j, value})
+			})
 		}
+	})
+
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+	for k, r := range aRows {
 		i := r - a.rowStart
+		for _, e := range results[k] {
+			mat.set(i+mat.rowStart, e.j+mat.colStart, e.value)
+		}
+	}
+}
+
+// accumulator is the sparse accumulator of Gustavson's algorithm, a dense row of values plus the
+// list of columns touched so far so emptying it costs only the number of those columns.
+type accumulator struct {
+	values  []int
+	touched []bool
+	cols    []int
+}
+
+func newAccumulator(cols int) *accumulator {
+	return &accumulator{
+		values:  make([]int, cols),
+		touched: make([]bool, cols),
+	}
+}
+
+// addRowProduct adds row r (a stored row index) of a times b to the accumulator, that is the
+// rows of b scaled by the nonzero values of the row of a.
+func (acc *accumulator) addRowProduct(a, b *Matrix, r int) {
+	for c, v1 := range a.rowValues()[r] {
+		if c < a.colStart || a.colStart+a.cols <= c {
+			continue
+		}
 
-		for c, rs := range b.colValues {
-			if c < b.colStart || b.colStart+b.cols <= c {
+		for cb, v2 := range b.rowValues()[c-a.colStart+b.rowStart] {
+			j := cb - b.colStart
+			if j < 0 || b.cols <= j {
 				continue
 			}
-			j := c - b.colStart
-			value := 0
-			for ics, v1 := range cs {
-				ci := ics - a.colStart
 
-				v2, ok := rs[ci+b.rowStart]
-				if ok {
-					value += v1 * v2
+			if !acc.touched[j] {
+				acc.touched[j] = true
+				acc.cols = append(acc.cols, j)
+			}
+			acc.values[j] += v1 * v2
+		}
+	}
+}
+
+// flush calls fn with every nonzero accumulated value in increasing column order and empties the
+// accumulator.
+func (acc *accumulator) flush(fn func(j, value int)) {
+	sort.Ints(acc.cols)
+	for _, j := range acc.cols {
+		if acc.values[j] != 0 {
+			fn(j, acc.values[j])
+		}
+		acc.values[j] = 0
+		acc.touched[j] = false
+	}
+	acc.cols = acc.cols[:0]
+}
+
+// BoolMul stores the Boolean (OR of ANDs) product of a and b in this matrix, any nonzero value is
+// treated as true. The value at (i,j) is 1 when some k has nonzero values at a(i,k) and b(k,j).
+func (mat *Matrix) BoolMul(a, b *Matrix) {
+	if a == nil || b == nil {
+		panic("boolean multiply input was found to be nil")
+	}
+
+	if mat == a || mat == b {
+		panic("boolean multiply self assignment not allowed")
+	}
+
+	if a.cols != b.rows {
+		panic(fmt.Sprintf("boolean multiply shape misalignment can't multiply (%v,%v)x(%v,%v)", a.rows, a.cols, b.rows, b.cols))
+	}
+
+	if mat.rows != a.rows || mat.cols != b.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, b.cols))
+	}
+
+	mat.boolMul(a, b)
+}
+
+func (mat *Matrix) boolMul(a, b *Matrix) {
+	rows := make(map[int]map[int]bool)
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		i := r - a.rowStart
+		for ac := range a.rowEntries(r) {
+			k := ac - a.colStart
+			for bc := range b.rowEntries(k + b.rowStart) {
+				if rows[i] == nil {
+					rows[i] = make(map[int]bool)
 				}
+				rows[i][bc-b.colStart] = true
 			}
+		}
+	}
 
-			mat.Set(i, j, value)
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+	for i, js := range rows {
+		for j := range js {
+			mat.set(i+mat.rowStart, j+mat.colStart, 1)
 		}
 	}
 }
 
+// TransitiveClosure returns a NEW matrix holding the transitive closure of this matrix viewed as a
+// relation (any nonzero value is true), computed with Warshall's algorithm. The value at (i,j) is 1
+// when j can be reached from i in one or more steps. The matrix must be square.
+func (mat *Matrix) TransitiveClosure() *Matrix {
+	if mat.rows != mat.cols {
+		panic(fmt.Sprintf("matrix must be square to take the transitive closure, got %dx%d", mat.rows, mat.cols))
+	}
+
+	closure := NewMat(mat.rows, mat.cols)
+	mat.DoNonzero(func(i, j, value int) {
+		closure.set(i, j, 1)
+	})
+
+	for k := 0; k < closure.rows; k++ {
+		reachK := closure.rowEntries(k)
+		for i := range closure.colValues()[k] {
+			for j := range reachK {
+				closure.set(i, j, 1)
+			}
+		}
+	}
+
+	return closure
+}
+
+// ReflexiveTransitiveClosure returns a NEW matrix holding the reflexive transitive closure of this
+// matrix viewed as a relation (any nonzero value is true). The value at (i,j) is 1 when j can be
+// reached from i in zero or more steps. It is computed by repeatedly squaring I OR mat with the
+// Boolean product, which takes at most log2(n) products. The matrix must be square.
+func (mat *Matrix) ReflexiveTransitiveClosure() *Matrix {
+	if mat.rows != mat.cols {
+		panic(fmt.Sprintf("matrix must be square to take the reflexive transitive closure, got %dx%d", mat.rows, mat.cols))
+	}
+
+	closure := Identity(mat.rows)
+	mat.DoNonzero(func(i, j, value int) {
+		closure.set(i, j, 1)
+	})
+
+	for reach := 1; reach < mat.rows; reach *= 2 {
+		squared := NewMat(mat.rows, mat.cols)
+		squared.boolMul(closure, closure)
+		if squared.Equals(closure) {
+			break
+		}
+		closure = squared
+	}
+
+	return closure
+}
+
 // Add stores the addition of a and b in this matrix.
 func (mat *Matrix) Add(a, b *Matrix) {
 	if a == nil || b == nil {
@@ -405,16 +968,82 @@ func (mat *Matrix) Add(a, b *Matrix) {
 }
 
 func (mat *Matrix) add(a, b *Matrix) {
+	mat.addScaled(a, 1, b)
+}
+
+// Sub stores the subtraction a-b in this matrix.
+func (mat *Matrix) Sub(a, b *Matrix) {
+	mat.checkLinear("subtraction", a, b)
+
+	mat.addScaled(a, -1, b)
+}
+
+// AddScaled stores a+s*b in this matrix.
+func (mat *Matrix) AddScaled(a *Matrix, s int, b *Matrix) {
+	mat.checkLinear("scaled addition", a, b)
+
+	mat.addScaled(a, s, b)
+}
+
+// Scale stores a with every value multiplied by s in this matrix, a may be this matrix.
+func (mat *Matrix) Scale(a *Matrix, s int) {
+	if a == nil {
+		panic("scale input was found to be nil")
+	}
+	if mat.rows != a.rows || mat.cols != a.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, a.cols))
+	}
+
+	mat.scale(a, s)
+}
+
+func (mat *Matrix) scale(a *Matrix, s int) {
+	// gather the values first, a may be another view overlapping this one
+	type entry struct {
+		i, j, value int
+	}
+	var entries []entry
+	if s != 0 {
+		a.DoNonzero(func(i, j, v int) {
+			entries = append(entries, entry{i, j, s * v})
+		})
+	}
+
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+	for _, e := range entries {
+		mat.set(e.i+mat.rowStart, e.j+mat.colStart, e.value)
+	}
+}
+
+// checkLinear panics unless a and b can be combined into this matrix.
+func (mat *Matrix) checkLinear(op string, a, b *Matrix) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+	if mat == a || mat == b {
+		panic(fmt.Sprintf("%v self assignment not allowed", op))
+	}
+
+	if a.rows != b.rows || a.cols != b.cols {
+		panic(fmt.Sprintf("%v input mat shapes do not match a=(%v,%v) b=(%v,%v)", op, a.rows, a.cols, b.rows, b.cols))
+	}
+	if mat.rows != a.rows || mat.cols != a.cols {
+		panic(fmt.Sprintf("mat shape (%v,%v) does not match expected (%v,%v)", mat.rows, mat.cols, a.rows, a.cols))
+	}
+}
+
+func (mat *Matrix) addScaled(a *Matrix, s int, b *Matrix) {
 	//first we need to clear mat
 	mat.setMatrix(a, mat.rowStart, mat.colStart)
+	if s == 0 {
+		return
+	}
 
-	for r, cs := range b.rowValues {
-		i := r - b.rowStart
-		mr := i + mat.rowStart
-		for c, v := range cs {
-			j := c - b.colStart
-			mc := j + mat.colStart
-			mat.set(mr, mc, mat.at(mr, mc)+v)
+	for _, r := range sortedKeys(b.rowValues(), b.rowStart, b.rows) {
+		mr := r - b.rowStart + mat.rowStart
+		for c, v := range b.rowEntries(r) {
+			mc := c - b.colStart + mat.colStart
+			mat.set(mr, mc, mat.at(mr, mc)+s*v)
 		}
 	}
 }
@@ -439,13 +1068,13 @@ func (mat *Matrix) SetColumn(j int, vec *TransposedVector) {
 	c := j + mat.colStart
 
 	//first we'll zeroize
-	rs := mat.colValues[c]
+	rs := mat.colValues()[c]
 	for r := range rs {
 		mat.set(r, c, 0)
 	}
 
 	//now set the new values
-	for i, v := range vec.mat.colValues[vec.mat.colStart] {
+	for i, v := range vec.mat.colValues()[vec.mat.colStart] {
 		r := i + mat.rowStart
 		mat.set(r, c, v)
 	}
@@ -471,18 +1100,137 @@ func (mat *Matrix) SetRow(i int, vec *Vector) {
 	r := i + mat.rowStart
 
 	//first we'll zeroize
-	cs := mat.rowValues[r]
+	cs := mat.rowValues()[r]
 	for c := range cs {
 		mat.set(r, c, 0)
 	}
 
 	//now set the new values
-	for j, v := range vec.mat.rowValues[vec.mat.rowStart] {
+	for j, v := range vec.mat.rowValues()[vec.mat.rowStart] {
 		c := j + mat.colStart
 		mat.set(r, c, v)
 	}
 }
 
+// SwapRows swaps rows i and k. Only the values in the two rows are touched.
+func (mat *Matrix) SwapRows(i, k int) {
+	mat.checkRowBounds(i)
+	mat.checkRowBounds(k)
+	if i == k {
+		return
+	}
+
+	r1 := i + mat.rowStart
+	r2 := k + mat.rowStart
+	cs1 := mat.rowEntries(r1)
+	cs2 := mat.rowEntries(r2)
+	for c := range cs1 {
+		mat.set(r1, c, 0)
+	}
+	for c := range cs2 {
+		mat.set(r2, c, 0)
+	}
+	for c, v := range cs1 {
+		mat.set(r2, c, v)
+	}
+	for c, v := range cs2 {
+		mat.set(r1, c, v)
+	}
+}
+
+// SwapCols swaps columns j and k. Only the values in the two columns are touched.
+func (mat *Matrix) SwapCols(j, k int) {
+	mat.T().SwapRows(j, k)
+}
+
+// PermuteRows moves row i to row perm[i] for every row. perm must be a permutation of [0,rows).
+func (mat *Matrix) PermuteRows(perm []int) {
+	checkPermutation(perm, mat.rows)
+
+	rows := make([]map[int]int, mat.rows)
+	for i := range rows {
+		r := i + mat.rowStart
+		rows[i] = mat.rowEntries(r)
+		for c := range rows[i] {
+			mat.set(r, c, 0)
+		}
+	}
+	for i, cs := range rows {
+		r := perm[i] + mat.rowStart
+		for c, v := range cs {
+			mat.set(r, c, v)
+		}
+	}
+}
+
+// PermuteCols moves column j to column perm[j] for every column. perm must be a permutation of [0,cols).
+func (mat *Matrix) PermuteCols(perm []int) {
+	mat.T().PermuteRows(perm)
+}
+
+// RemoveRows returns a NEW matrix holding the rows of this matrix that are not listed in rows.
+func (mat *Matrix) RemoveRows(rows ...int) *Matrix {
+	removed := make(map[int]bool, len(rows))
+	for _, i := range rows {
+		mat.checkRowBounds(i)
+		removed[i] = true
+	}
+
+	result := NewMat(mat.rows-len(removed), mat.cols)
+	ri := 0
+	for i := 0; i < mat.rows; i++ {
+		if removed[i] {
+			continue
+		}
+		for c, v := range mat.rowEntries(i + mat.rowStart) {
+			result.set(ri, c-mat.colStart, v)
+		}
+		ri++
+	}
+
+	return result
+}
+
+// RemoveCols returns a NEW matrix holding the columns of this matrix that are not listed in cols.
+func (mat *Matrix) RemoveCols(cols ...int) *Matrix {
+	return mat.T().RemoveRows(cols...).T()
+}
+
+// InsertZeroRows returns a NEW matrix with count rows of zeros inserted before row i of this matrix.
+// An i equal to the number of rows appends the zero rows at the bottom.
+func (mat *Matrix) InsertZeroRows(i, count int) *Matrix {
+	if i < 0 || i > mat.rows {
+		panic(fmt.Sprintf("%v out of range: [0-%v]", i, mat.rows))
+	}
+	if count < 0 {
+		panic("count must be non-negative")
+	}
+
+	result := NewMat(mat.rows+count, mat.cols)
+	result.setMatrix(mat.slice(mat.rowStart, mat.colStart, i, mat.cols), 0, 0)
+	result.setMatrix(mat.slice(mat.rowStart+i, mat.colStart, mat.rows-i, mat.cols), i+count, 0)
+
+	return result
+}
+
+// InsertZeroCols returns a NEW matrix with count columns of zeros inserted before column j of this matrix.
+// A j equal to the number of columns appends the zero columns on the right.
+func (mat *Matrix) InsertZeroCols(j, count int) *Matrix {
+	return mat.T().InsertZeroRows(j, count).T()
+}
+
+// rowEntries returns the values of row r that are inside this matrix's columns, keyed by column.
+func (mat *Matrix) rowEntries(r int) map[int]int {
+	entries := make(map[int]int)
+	for c, v := range mat.rowValues()[r] {
+		if c < mat.colStart || mat.colStart+mat.cols <= c {
+			continue
+		}
+		entries[c] = v
+	}
+	return entries
+}
+
 // Equals return true if the m matrix has the same shape and values as mat matrix.
 func (mat *Matrix) Equals(m *Matrix) bool {
 	if mat == m {
@@ -499,9 +1247,9 @@ func (mat *Matrix) Equals(m *Matrix) bool {
 
 	for i := 0; i < mat.rows; i++ {
 		r := i + mat.rowStart
-		cs, ok1 := mat.rowValues[r]
+		cs, ok1 := mat.rowValues()[r]
 		ar := i + m.rowStart
-		acs, ok2 := m.rowValues[ar]
+		acs, ok2 := m.rowValues()[ar]
 
 		if !ok1 && !ok2 {
 			continue
@@ -551,6 +1299,17 @@ func (mat Matrix) String() string {
 	return buff.String()
 }
 
+// DoNonzero calls fn for each nonzero value in the matrix. Values are visited in row-major order with
+// row and column indices increasing, so the order is the same from run to run.
+func (mat *Matrix) DoNonzero(fn func(i, j, value int)) {
+	for _, r := range sortedKeys(mat.rowValues(), mat.rowStart, mat.rows) {
+		cs := mat.rowValues()[r]
+		for _, c := range sortedInnerKeys(cs, mat.colStart, mat.cols) {
+			fn(r-mat.rowStart, c-mat.colStart, cs[c])
+		}
+	}
+}
+
 // SetMatrix replaces the values of this matrix with the values of from matrix a. The shape of 'a' must be less than or equal mat.
 // If the 'a' shape is less then iOffset and jOffset can be used to place 'a' matrix in a specific location.
 func (mat *Matrix) SetMatrix(a *Matrix, iOffset, jOffset int) {
@@ -567,10 +1326,16 @@ func (mat *Matrix) SetMatrix(a *Matrix, iOffset, jOffset int) {
 func (mat *Matrix) setMatrix(a *Matrix, rOffset, cOffset int) {
 	mat.zeroize(rOffset, cOffset, a.rows, a.cols)
 
-	for r, cs := range a.rowValues {
+	for r, cs := range a.rowValues() {
+		if r < a.rowStart || a.rowStart+a.rows <= r {
+			continue
+		}
 		i := r - a.rowStart
 		mr := i + rOffset
 		for c, v := range cs {
+			if c < a.colStart || a.colStart+a.cols <= c {
+				continue
+			}
 			j := c - a.colStart
 			mc := j + cOffset
 			mat.set(mr, mc, v)
@@ -615,21 +1380,16 @@ func (mat *Matrix) and(a, b *Matrix) {
 	//first we need to clear mat
 	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
 
-	for r, cs1 := range a.rowValues {
-		if r < a.rowStart || a.rowStart+a.rows <= r {
-			continue
-		}
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		cs1 := a.rowValues()[r]
 		i := r - a.rowStart
 
-		cs2, has := b.rowValues[i+b.rowStart]
+		cs2, has := b.rowValues()[i+b.rowStart]
 		if !has {
 			continue
 		}
 
-		for c, _ := range cs1 {
-			if c < a.colStart || a.colStart+a.cols <= c {
-				continue
-			}
+		for _, c := range sortedInnerKeys(cs1, a.colStart, a.cols) {
 			j := c - a.colStart
 
 			_, has := cs2[c]
@@ -666,29 +1426,19 @@ func (mat *Matrix) or(a, b *Matrix) {
 	//first we need to clear mat
 	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
 
-	for r, cs1 := range a.rowValues {
-		if r < a.rowStart || a.rowStart+a.rows <= r {
-			continue
-		}
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		cs1 := a.rowValues()[r]
 		i := r - a.rowStart
-		for c, _ := range cs1 {
-			if c < a.colStart || a.colStart+a.cols <= c {
-				continue
-			}
+		for _, c := range sortedInnerKeys(cs1, a.colStart, a.cols) {
 			j := c - a.colStart
 			mat.Set(i, j, 1)
 		}
 	}
 
-	for r, cs1 := range b.rowValues {
-		if r < b.rowStart || b.rowStart+b.rows <= r {
-			continue
-		}
+	for _, r := range sortedKeys(b.rowValues(), b.rowStart, b.rows) {
+		cs1 := b.rowValues()[r]
 		i := r - b.rowStart
-		for c, _ := range cs1 {
-			if c < b.colStart || b.colStart+b.cols <= c {
-				continue
-			}
+		for _, c := range sortedInnerKeys(cs1, b.colStart, b.cols) {
 			j := c - b.colStart
 			mat.Set(i, j, 1)
 		}
@@ -720,15 +1470,10 @@ func (mat *Matrix) xor(a, b *Matrix) {
 	//first we need to clear mat
 	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
 
-	for r, cs1 := range a.rowValues {
-		if r < a.rowStart || a.rowStart+a.rows <= r {
-			continue
-		}
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		cs1 := a.rowValues()[r]
 		i := r - a.rowStart
-		for c, _ := range cs1 {
-			if c < a.colStart || a.colStart+a.cols <= c {
-				continue
-			}
+		for _, c := range sortedInnerKeys(cs1, a.colStart, a.cols) {
 			j := c - a.colStart
 
 			if b.at(i+b.rowStart, j+b.colStart) == 1 {
@@ -739,15 +1484,10 @@ func (mat *Matrix) xor(a, b *Matrix) {
 		}
 	}
 
-	for r, cs1 := range b.rowValues {
-		if r < b.rowStart || b.rowStart+b.rows <= r {
-			continue
-		}
+	for _, r := range sortedKeys(b.rowValues(), b.rowStart, b.rows) {
+		cs1 := b.rowValues()[r]
 		i := r - b.rowStart
-		for c, _ := range cs1 {
-			if c < b.colStart || b.colStart+b.cols <= c {
-				continue
-			}
+		for _, c := range sortedInnerKeys(cs1, b.colStart, b.cols) {
 			j := c - b.colStart
 			if a.at(i+a.rowStart, j+a.colStart) == 1 {
 				continue
@@ -757,3 +1497,77 @@ func (mat *Matrix) xor(a, b *Matrix) {
 		}
 	}
 }
+
+// sortedKeys returns the keys of values inside [start,start+length) in increasing order.
+func sortedKeys(values map[int]map[int]int, start, length int) []int {
+	keys := make([]int, 0, len(values))
+	for k := range values {
+		if k < start || start+length <= k {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// sortedInnerKeys returns the keys of values inside [start,start+length) in increasing order.
+func sortedInnerKeys(values map[int]int, start, length int) []int {
+	keys := make([]int, 0, len(values))
+	for k := range values {
+		if k < start || start+length <= k {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// checkPermutation panics unless perm holds every index in [0,n) exactly once.
+func checkPermutation(perm []int, n int) {
+	if len(perm) != n {
+		panic(fmt.Sprintf("permutation length (%v) does not match expected %v", len(perm), n))
+	}
+
+	seen := make([]bool, n)
+	for _, p := range perm {
+		if p < 0 || p >= n || seen[p] {
+			panic(fmt.Sprintf("%v is not a permutation of [0-%v]", perm, n-1))
+		}
+		seen[p] = true
+	}
+}
+
+// mulMod returns a*b mod m for a and b in [0,m) without overflowing.
+func mulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// addMod returns a+b mod m for a and b in [0,m) without overflowing.
+func addMod(a, b, m int) int {
+	return int((uint64(a) + uint64(b)) % uint64(m))
+}
+
+// parallelRows splits [0,n) into contiguous chunks, calls fn with the bounds of each chunk on its
+// own goroutine and returns once all calls are done. A workers value less than 1 uses
+// runtime.GOMAXPROCS(0).
+func parallelRows(n, workers int, fn func(lo, hi int)) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(w*n/workers, (w+1)*n/workers)
+	}
+	wg.Wait()
+}
@@ -2,7 +2,13 @@ package intmat
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -189,6 +195,71 @@ func TestIdentity(t *testing.T) {
 	}
 }
 
+func TestCirculant(t *testing.T) {
+	tests := []struct {
+		first    *Vector
+		expected *Matrix
+	}{
+		{NewVec(1, 1), NewMat(1, 1, 1)},
+		{NewVec(3, 1, 2, 0), NewMat(3, 3, 1, 2, 0, 0, 1, 2, 2, 0, 1)},
+		{NewVec(4, 0, 1, 0, 1), NewMat(4, 4, 0, 1, 0, 1, 1, 0, 1, 0, 0, 1, 0, 1, 1, 0, 1, 0)},
+		{NewVec(6, 0, 1, 1, 0, 0, 1).Slice(1, 3), NewMat(3, 3, 1, 1, 0, 0, 1, 1, 1, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Circulant(test.first)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCirculantPermutation(t *testing.T) {
+	tests := []struct {
+		size, shift int
+		expected    *Matrix
+	}{
+		{3, 0, Identity(3)},
+		{3, 1, NewMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0)},
+		{3, 5, NewMat(3, 3, 0, 0, 1, 1, 0, 0, 0, 1, 0)},
+		{3, -1, NewMat(3, 3, 0, 0, 1, 1, 0, 0, 0, 1, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := CirculantPermutation(test.size, test.shift)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestQuasiCyclic(t *testing.T) {
+	tests := []struct {
+		base     [][]int
+		z        int
+		expected *Matrix
+	}{
+		{[][]int{{0}}, 3, Identity(3)},
+		{[][]int{{-1}}, 2, NewMat(2, 2)},
+		{[][]int{{1, -1}, {0, 3}}, 2, NewMat(4, 4,
+			0, 1, 0, 0,
+			1, 0, 0, 0,
+			1, 0, 0, 1,
+			0, 1, 1, 0,
+		)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := QuasiCyclic(test.base, test.z)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
 func TestMatrix_At(t *testing.T) {
 	tests := []struct {
 		input    *Matrix
@@ -230,6 +301,117 @@ func TestMatrix_Mul(t *testing.T) {
 	}
 }
 
+func TestMatrix_Mul_Views(t *testing.T) {
+	// both inputs have values just outside their windows that must not be paired up
+	a := NewMat(2, 3, 1, 2, 3, 4, 5, 6).Slice(0, 0, 2, 2)
+	b := NewMat(3, 2, 1, 0, 0, 1, 1, 1).Slice(0, 0, 2, 2)
+	actual := NewMat(2, 2)
+	actual.Mul(a, b)
+	expected := NewMat(2, 2, 1, 2, 4, 5)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestMatrix_Mul_Deterministic(t *testing.T) {
+	a, b := NewMat(3, 8), NewMat(8, 20)
+	for i := 0; i < 3; i++ {
+		for k := 0; k < 8; k++ {
+			a.Set(i, k, i+k+1)
+		}
+	}
+	for k := 0; k < 8; k++ {
+		for j := k % 2; j < 20; j += 2 {
+			b.Set(k, j, k-j)
+		}
+	}
+
+	// the accumulator hands out each row in column order whatever order the maps are walked in
+	acc := newAccumulator(20)
+	for r := 0; r < 3; r++ {
+		acc.addRowProduct(a, b, r)
+		var cols []int
+		acc.flush(func(j, _ int) {
+			cols = append(cols, j)
+		})
+		if !sort.IntsAreSorted(cols) {
+			t.Fatalf("expected row %v in column order but found %v", r, cols)
+		}
+	}
+
+	first, second, parallel := NewMat(3, 20), NewMat(3, 20), NewMat(3, 20)
+	first.Mul(a, b)
+	second.Mul(a, b)
+	parallel.MulParallel(a, b, 2)
+	if first.String() != second.String() || first.String() != parallel.String() {
+		t.Fatalf("expected identical results but found:\n%v\n%v\n%v", first, second, parallel)
+	}
+}
+
+func TestMatrix_BoolMul(t *testing.T) {
+	tests := []struct {
+		a, b, result, expected *Matrix
+	}{
+		{Identity(2), Identity(2), NewMat(2, 2), Identity(2)},
+		{NewMat(2, 2, 1, 1, 1, 1), NewMat(2, 2, 1, 1, 1, 1), NewMat(2, 2), NewMat(2, 2, 1, 1, 1, 1)},
+		{NewMat(2, 3, 1, 0, 1, 0, 0, 0), NewMat(3, 2, 0, 1, 0, 0, 1, 1), NewMat(2, 2, 1, 1, 1, 1), NewMat(2, 2, 1, 1, 0, 0)},
+		{NewMat(3, 3, 0, 0, 0, 0, 2, 3, 0, 0, 0).Slice(1, 1, 1, 2), NewMat(2, 1, 0, 5), NewMat(1, 1), NewMat(1, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.result.BoolMul(test.a, test.b)
+			if !test.result.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, test.result)
+			}
+		})
+	}
+}
+
+func TestMatrix_TransitiveClosure(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		expected *Matrix
+	}{
+		{NewMat(3, 3), NewMat(3, 3)},
+		{NewMat(3, 3, 0, 1, 0, 0, 0, 1, 0, 0, 0), NewMat(3, 3, 0, 1, 1, 0, 0, 1, 0, 0, 0)},
+		{NewMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0), NewMat(3, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1)},
+		{NewMat(4, 4, 0, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 1, 0), NewMat(4, 4, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0, 1, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.TransitiveClosure()
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_ReflexiveTransitiveClosure(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		expected *Matrix
+	}{
+		{NewMat(3, 3), Identity(3)},
+		{NewMat(3, 3, 0, 1, 0, 0, 0, 1, 0, 0, 0), NewMat(3, 3, 1, 1, 1, 0, 1, 1, 0, 0, 1)},
+		{NewMat(5, 5, 0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0), NewMat(5, 5, 1, 1, 1, 1, 1, 0, 1, 1, 1, 1, 0, 0, 1, 1, 1, 0, 0, 0, 1, 1, 0, 0, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.ReflexiveTransitiveClosure()
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+
+			warshall := test.m.TransitiveClosure()
+			warshall.Or(Copy(warshall), Identity(warshall.rows))
+			if !actual.Equals(warshall) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", warshall, actual)
+			}
+		})
+	}
+}
+
 func TestMatrix_Zeroize(t *testing.T) {
 	tests := []struct {
 		original *Matrix
@@ -332,6 +514,17 @@ func TestMatrix_Add2(t *testing.T) {
 	}
 }
 
+func TestMatrix_Add_Views(t *testing.T) {
+	m := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	// the destination is a view too so values written outside its window would show up in big
+	big := NewMat(3, 3)
+	big.Slice(1, 1, 2, 2).Add(m.Slice(0, 0, 2, 2), m.Slice(1, 1, 2, 2))
+	expected := NewMat(3, 3, 0, 0, 0, 0, 6, 8, 0, 12, 14)
+	if !big.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, big)
+	}
+}
+
 func TestMatrix_Column(t *testing.T) {
 	tests := []struct {
 		m        *Matrix
@@ -414,6 +607,148 @@ func TestMatrix_SetRow(t *testing.T) {
 	}
 }
 
+func TestMatrix_SwapRows(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		i, k     int
+		expected *Matrix
+	}{
+		{NewMat(3, 2, 1, 2, 3, 4, 5, 6), 0, 2, NewMat(3, 2, 5, 6, 3, 4, 1, 2)},
+		{NewMat(3, 2, 1, 2, 3, 4, 5, 6), 1, 1, NewMat(3, 2, 1, 2, 3, 4, 5, 6)},
+		{NewMat(2, 2, 1, 0, 0, 0), 0, 1, NewMat(2, 2, 0, 0, 1, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			test.m.SwapRows(test.i, test.k)
+			if !test.m.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, test.m)
+			}
+		})
+	}
+}
+
+func TestMatrix_SwapRows2(t *testing.T) {
+	m := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	m.Slice(0, 1, 3, 2).SwapRows(0, 2)
+
+	expected := NewMat(3, 3, 1, 8, 9, 4, 5, 6, 7, 2, 3)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestMatrix_SwapCols(t *testing.T) {
+	m := NewMat(2, 3, 1, 2, 3, 4, 5, 6)
+	m.SwapCols(0, 2)
+
+	expected := NewMat(2, 3, 3, 2, 1, 6, 5, 4)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestMatrix_PermuteRows(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        *Matrix
+		perm     []int
+		expected *Matrix
+		panic    bool
+	}{
+		{"identity", NewMat(3, 1, 1, 2, 3), []int{0, 1, 2}, NewMat(3, 1, 1, 2, 3), false},
+		{"cycle", NewMat(3, 1, 1, 2, 3), []int{1, 2, 0}, NewMat(3, 1, 3, 1, 2), false},
+		{"slice", NewMat(4, 1, 1, 2, 3, 4).Slice(1, 0, 2, 1), []int{1, 0}, NewMat(2, 1, 3, 2), false},
+		{"short", NewMat(3, 1, 1, 2, 3), []int{1, 0}, nil, true},
+		{"repeat", NewMat(3, 1, 1, 2, 3), []int{1, 1, 0}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if test.panic && r == nil {
+					t.Errorf("expected panic but did not get one")
+				}
+				if !test.panic && r != nil {
+					t.Errorf("did not expect panic but got: %v", r)
+				}
+			}()
+
+			test.m.PermuteRows(test.perm)
+			if !test.m.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, test.m)
+			}
+		})
+	}
+}
+
+func TestMatrix_PermuteCols(t *testing.T) {
+	m := NewMat(2, 3, 1, 2, 3, 4, 5, 6)
+	m.PermuteCols([]int{2, 0, 1})
+
+	expected := NewMat(2, 3, 2, 3, 1, 5, 6, 4)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestMatrix_RemoveRows(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		rows     []int
+		expected *Matrix
+	}{
+		{NewMat(3, 2, 1, 2, 3, 4, 5, 6), []int{1}, NewMat(2, 2, 1, 2, 5, 6)},
+		{NewMat(3, 2, 1, 2, 3, 4, 5, 6), []int{2, 0, 2}, NewMat(1, 2, 3, 4)},
+		{NewMat(3, 2, 1, 2, 3, 4, 5, 6), nil, NewMat(3, 2, 1, 2, 3, 4, 5, 6)},
+		{NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9).Slice(1, 1, 2, 2), []int{0}, NewMat(1, 2, 8, 9)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.RemoveRows(test.rows...)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_RemoveCols(t *testing.T) {
+	actual := NewMat(2, 3, 1, 2, 3, 4, 5, 6).RemoveCols(0, 2)
+	expected := NewMat(2, 1, 2, 5)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestMatrix_InsertZeroRows(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		i, count int
+		expected *Matrix
+	}{
+		{NewMat(2, 2, 1, 2, 3, 4), 1, 1, NewMat(3, 2, 1, 2, 0, 0, 3, 4)},
+		{NewMat(2, 2, 1, 2, 3, 4), 0, 2, NewMat(4, 2, 0, 0, 0, 0, 1, 2, 3, 4)},
+		{NewMat(2, 2, 1, 2, 3, 4), 2, 1, NewMat(3, 2, 1, 2, 3, 4, 0, 0)},
+		{NewMat(2, 2, 1, 2, 3, 4), 1, 0, NewMat(2, 2, 1, 2, 3, 4)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.m.InsertZeroRows(test.i, test.count)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_InsertZeroCols(t *testing.T) {
+	actual := NewMat(2, 2, 1, 2, 3, 4).InsertZeroCols(1, 1)
+	expected := NewMat(2, 3, 1, 0, 2, 3, 0, 4)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
 func TestMatrix_SetMatrix(t *testing.T) {
 	tests := []struct {
 		dest             *Matrix
@@ -467,6 +802,105 @@ func TestMatrix_SetMatrix2(t *testing.T) {
 	}
 }
 
+func TestMatrix_SetMatrix3(t *testing.T) {
+	source := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	m := NewMat(3, 3)
+	m.SetMatrix(source.Slice(1, 1, 2, 2), 0, 1)
+
+	expected := NewMat(3, 3, 0, 5, 6, 0, 8, 9, 0, 0, 0)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestKron(t *testing.T) {
+	tests := []struct {
+		a, b, expected *Matrix
+	}{
+		{Identity(2), NewMat(1, 2, 1, 2), NewMat(2, 4, 1, 2, 0, 0, 0, 0, 1, 2)},
+		{NewMat(1, 2, 2, 3), Identity(2), NewMat(2, 4, 2, 0, 3, 0, 0, 2, 0, 3)},
+		{NewMat(2, 2, 1, 1, 0, 1), NewMat(2, 1, 1, -1), NewMat(4, 2, 1, 1, -1, -1, 0, 1, 0, -1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := Kron(test.a, test.b)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDirectSum(t *testing.T) {
+	tests := []struct {
+		ms       []*Matrix
+		expected *Matrix
+	}{
+		{[]*Matrix{Identity(1), Identity(2)}, Identity(3)},
+		{[]*Matrix{NewMat(1, 2, 1, 2), NewMat(2, 1, 3, 4)}, NewMat(3, 3, 1, 2, 0, 0, 0, 3, 0, 0, 4)},
+		{[]*Matrix{NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9).Slice(1, 1, 2, 2), Identity(1)}, NewMat(3, 3, 5, 6, 0, 8, 9, 0, 0, 0, 1)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := DirectSum(test.ms...)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHStack(t *testing.T) {
+	actual := HStack(Identity(2), NewMat(2, 1, 3, 4))
+	expected := NewMat(2, 3, 1, 0, 3, 0, 1, 4)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestVStack(t *testing.T) {
+	actual := VStack(Identity(2), NewMat(1, 2, 3, 4))
+	expected := NewMat(3, 2, 1, 0, 0, 1, 3, 4)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+}
+
+func TestBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		blocks   [][]*Matrix
+		expected *Matrix
+		panic    bool
+	}{
+		{"single", [][]*Matrix{{Identity(2)}}, Identity(2), false},
+		{"zero_blocks", [][]*Matrix{{Identity(2), nil}, {nil, NewMat(1, 1, 5)}}, NewMat(3, 3, 1, 0, 0, 0, 1, 0, 0, 0, 5), false},
+		{"full", [][]*Matrix{{NewMat(1, 1, 1), NewMat(1, 2, 2, 3)}, {NewMat(1, 1, 4), NewMat(1, 2, 5, 6)}}, NewMat(2, 3, 1, 2, 3, 4, 5, 6), false},
+		{"height_mismatch", [][]*Matrix{{Identity(2), Identity(1)}}, nil, true},
+		{"width_mismatch", [][]*Matrix{{Identity(2)}, {Identity(1)}}, nil, true},
+		{"all_nil_row", [][]*Matrix{{Identity(2)}, {nil}}, nil, true},
+		{"ragged", [][]*Matrix{{Identity(2), Identity(2)}, {Identity(2)}}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if test.panic && r == nil {
+					t.Errorf("expected panic but did not get one")
+				}
+				if !test.panic && r != nil {
+					t.Errorf("did not expect panic but got: %v", r)
+				}
+			}()
+
+			actual := Block(test.blocks)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected \n%v\n but found \n%v\n", test.expected, actual)
+			}
+		})
+	}
+}
+
 func TestMatrix_JSON(t *testing.T) {
 	m := Identity(3)
 
@@ -485,6 +919,29 @@ func TestMatrix_JSON(t *testing.T) {
 	}
 }
 
+func TestMatrix_DoNonzero(t *testing.T) {
+	tests := []struct {
+		m        *Matrix
+		expected [][3]int
+	}{
+		{NewMat(2, 2), nil},
+		{NewMat(3, 3, 0, 1, 1, 1, 0, 0, 0, 0, 2), [][3]int{{0, 1, 1}, {0, 2, 1}, {1, 0, 1}, {2, 2, 2}}},
+		{NewMat(3, 3, 0, 1, 1, 1, 0, 0, 0, 0, 2).Slice(1, 1, 2, 2), [][3]int{{1, 1, 2}}},
+		{NewMat(2, 3, 1, 0, 3, 0, 2, 0).T(), [][3]int{{0, 0, 1}, {1, 1, 2}, {2, 0, 3}}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			var actual [][3]int
+			test.m.DoNonzero(func(i, j, value int) {
+				actual = append(actual, [3]int{i, j, value})
+			})
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
 func TestMatrix_And(t *testing.T) {
 	tests := []struct {
 		x, y, result, expected *Matrix
@@ -603,3 +1060,372 @@ func TestMatrix_Pow(t *testing.T) {
 		})
 	}
 }
+
+func TestMatrix_PowMod(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        *Matrix
+		k        *big.Int
+		mod      int
+		expected *Matrix
+	}{
+		{"pow_0", NewMat(2, 2, 1, 2, 3, 4), big.NewInt(0), 5, Identity(2)},
+		{"pow_0_mod_1", NewMat(2, 2, 1, 2, 3, 4), big.NewInt(0), 1, NewMat(2, 2)},
+		{"pow_3", NewMat(2, 2, 1, 2, 3, 4), big.NewInt(3), 10, NewMat(2, 2, 7, 4, 1, 8)},
+		{"negative_entries", NewMat(2, 2, -1, 0, 0, -3), big.NewInt(1), 7, NewMat(2, 2, 6, 0, 0, 4)},
+		// walks of length 2^64 in a 3-cycle return to the start since 2^64 = 1 mod 3
+		{"cycle_walks", NewMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0), new(big.Int).Lsh(big.NewInt(1), 64), 2, NewMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0)},
+		// 2^62 squared overflows an int, the modular product must not
+		{"large_modulus", NewMat(1, 1, 1<<62), big.NewInt(2), 1<<62 + 1, NewMat(1, 1, 1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.m.PowMod(test.k, test.mod)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected:\n%v\nbut found:\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_PowMod_LargeModulus(t *testing.T) {
+	// reducing entries must not overflow once m is above half the int range
+	const m = 1<<63 - 2
+	actual := NewMat(1, 3, m-1, -(m - 1), -1)
+	actual.mod(m)
+	expected := NewMat(1, 3, m-1, 1, m-1)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	square := NewMat(2, 2, m-1, 0, 0, 2)
+	expected = NewMat(2, 2, 1, 0, 0, 4)
+	if actual := square.PowMod(big.NewInt(2), m); !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestMatrix_PowMod_Pow(t *testing.T) {
+	m := NewMat(3, 3, 1, 1, 0, 0, 2, 1, 1, 0, 3)
+	for k := 0; k < 8; k++ {
+		expected := m.Pow(k)
+		expected.mod(11)
+		actual := m.PowMod(big.NewInt(int64(k)), 11)
+		if !actual.Equals(expected) {
+			t.Fatalf("k=%v expected:\n%v\nbut found:\n%v", k, expected, actual)
+		}
+	}
+}
+
+func TestMatrix_MulParallel(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	random := func(rows, cols int) *Matrix {
+		m := NewMat(rows, cols)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if rng.Intn(3) == 0 {
+					m.Set(i, j, rng.Intn(11)-5)
+				}
+			}
+		}
+		return m
+	}
+
+	a := random(23, 17)
+	b := random(17, 31)
+	expected := NewMat(23, 31)
+	expected.Mul(a, b)
+
+	for _, workers := range []int{0, 1, 2, 5, 64} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			actual := NewMat(23, 31)
+			actual.Set(0, 0, 99) // stale values must be cleared
+			actual.MulParallel(a, b, workers)
+			if !actual.Equals(expected) {
+				t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+			}
+		})
+	}
+
+	// views of a larger matrix
+	views := random(30, 30)
+	as, bs := views.Slice(2, 3, 10, 12), views.Slice(5, 1, 12, 7)
+	expected = NewMat(10, 7)
+	expected.Mul(as, bs)
+	actual := NewMat(10, 7)
+	actual.MulParallel(as, bs, 3)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
+
+func TestMatrix_Snapshot(t *testing.T) {
+	m := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	view := m.Slice(1, 1, 2, 2)
+	snapshot := m.Snapshot()
+	snapshotT := snapshot.T()
+
+	m.Set(0, 0, 10)
+	if snapshot.At(0, 0) != 1 || snapshotT.At(0, 0) != 1 {
+		t.Fatalf("snapshot saw a write to the original")
+	}
+	if view.At(0, 0) != 5 {
+		t.Fatalf("expected view to still share the original")
+	}
+	view.Set(0, 0, 11)
+	if m.At(1, 1) != 11 || snapshot.At(1, 1) != 5 {
+		t.Fatalf("expected view writes to reach only the original")
+	}
+
+	snapshot.Set(2, 2, 0)
+	if m.At(2, 2) != 9 || snapshotT.At(2, 2) != 0 {
+		t.Fatalf("expected snapshot writes to reach only the snapshot and its views")
+	}
+
+	expected := NewMat(3, 3, 10, 2, 3, 4, 11, 6, 7, 8, 9)
+	if !m.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+	expected = NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 0)
+	if !snapshot.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, snapshot)
+	}
+}
+
+func TestMatrix_Snapshot_View(t *testing.T) {
+	m := NewMat(3, 4, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)
+	snapshot := m.Slice(1, 1, 2, 3).T().Snapshot()
+	m.Zeroize()
+
+	expected := NewMat(3, 2, 6, 10, 7, 11, 8, 12)
+	if !snapshot.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, snapshot)
+	}
+}
+
+func TestMatrix_Snapshot_CopiesOnce(t *testing.T) {
+	m := NewMat(2, 2, 1, 2, 3, 4)
+	snapshot := m.Snapshot()
+	snapshot.Set(0, 0, 5)
+
+	// the snapshot took the copy so the original is no longer shared
+	rowValues := reflect.ValueOf(m.store.rowValues).Pointer()
+	m.Set(0, 0, 6)
+	if reflect.ValueOf(m.store.rowValues).Pointer() != rowValues {
+		t.Fatalf("expected the original to keep its storage")
+	}
+
+	// powers do not make the original copy either, even when nothing is left to reduce
+	m.Pow(3)
+	m.PowMod(big.NewInt(3), 5)
+	zero := NewMat(2, 2)
+	zero.PowMod(big.NewInt(3), 5)
+	m.Set(0, 1, 7)
+	if reflect.ValueOf(m.store.rowValues).Pointer() != rowValues {
+		t.Fatalf("expected the original to keep its storage")
+	}
+	zeroValues := reflect.ValueOf(zero.store.rowValues).Pointer()
+	zero.Set(0, 1, 7)
+	if reflect.ValueOf(zero.store.rowValues).Pointer() != zeroValues {
+		t.Fatalf("expected the zero matrix to keep its storage")
+	}
+}
+
+func TestMatrix_Snapshot_Concurrent(t *testing.T) {
+	m := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	expected := Copy(m)
+
+	snapshots := make([]*Matrix, 8)
+	var wg sync.WaitGroup
+	for k := range snapshots {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			snapshots[k] = m.Snapshot()
+		}(k)
+	}
+	wg.Wait()
+
+	m.Set(0, 0, 10)
+	for _, snapshot := range snapshots {
+		if !snapshot.Equals(expected) {
+			t.Fatalf("expected:\n%v\nbut found:\n%v", expected, snapshot)
+		}
+	}
+}
+
+func TestMatrix_Snapshot_ZeroValue(t *testing.T) {
+	var m Matrix
+	snapshot := m.Snapshot()
+	if rows, cols := snapshot.Dims(); rows != 0 || cols != 0 {
+		t.Fatalf("expected an empty matrix but found (%v,%v)", rows, cols)
+	}
+}
+
+// randomSparse returns a rows x cols matrix where each value is nonzero with probability density.
+func randomSparse(rng *rand.Rand, rows, cols int, density float64) *Matrix {
+	m := NewMat(rows, cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if rng.Float64() < density {
+				m.Set(i, j, rng.Intn(9)-4)
+			}
+		}
+	}
+	return m
+}
+
+// mulInnerProduct is the inner product multiply Mul used before Gustavson's algorithm, it is kept
+// to check and benchmark against.
+func mulInnerProduct(mat, a, b *Matrix) {
+	mat.zeroize(mat.rowStart, mat.colStart, mat.rows, mat.cols)
+
+	bCols := sortedKeys(b.colValues(), b.colStart, b.cols)
+	for _, r := range sortedKeys(a.rowValues(), a.rowStart, a.rows) {
+		cs := a.rowValues()[r]
+		i := r - a.rowStart
+
+		for _, c := range bCols {
+			rs := b.colValues()[c]
+			j := c - b.colStart
+			value := 0
+			for ics, v1 := range cs {
+				if ics < a.colStart || a.colStart+a.cols <= ics {
+					continue
+				}
+				ci := ics - a.colStart
+
+				v2, ok := rs[ci+b.rowStart]
+				if ok {
+					value += v1 * v2
+				}
+			}
+
+			mat.Set(i, j, value)
+		}
+	}
+}
+
+func TestMatrix_Mul_InnerProduct(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, density := range []float64{0.05, 0.3, 1} {
+		m := randomSparse(rng, 40, 40, density)
+		a, b := m.Slice(0, 3, 25, 30), m.T().Slice(5, 2, 30, 20)
+
+		expected := NewMat(25, 20)
+		mulInnerProduct(expected, a, b)
+		actual := NewMat(25, 20)
+		actual.Mul(a, b)
+		if !actual.Equals(expected) {
+			t.Fatalf("density %v expected:\n%v\nbut found:\n%v", density, expected, actual)
+		}
+	}
+}
+
+func BenchmarkMatrix_Mul(b *testing.B) {
+	for _, size := range []int{100, 500} {
+		for _, density := range []float64{0.01, 0.1} {
+			rng := rand.New(rand.NewSource(1))
+			x := randomSparse(rng, size, size, density)
+			y := randomSparse(rng, size, size, density)
+			name := fmt.Sprintf("%v_%v", size, density)
+
+			b.Run("gustavson_"+name, func(b *testing.B) {
+				mat := NewMat(size, size)
+				for n := 0; n < b.N; n++ {
+					mat.Mul(x, y)
+				}
+			})
+			b.Run("inner_product_"+name, func(b *testing.B) {
+				mat := NewMat(size, size)
+				for n := 0; n < b.N; n++ {
+					mulInnerProduct(mat, x, y)
+				}
+			})
+		}
+	}
+}
+
+func TestMatrix_Sub(t *testing.T) {
+	a := NewMat(2, 3, 1, 2, 0, 4, 0, 6)
+	b := NewMat(2, 3, 1, 0, 3, 5, 0, -6)
+	actual := NewMat(2, 3, 9, 9, 9, 9, 9, 9)
+	actual.Sub(a, b)
+	expected := NewMat(2, 3, 0, 2, -3, -1, 0, 12)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	// the inputs are left untouched
+	if !a.Equals(NewMat(2, 3, 1, 2, 0, 4, 0, 6)) || !b.Equals(NewMat(2, 3, 1, 0, 3, 5, 0, -6)) {
+		t.Fatalf("inputs were modified:\n%v\n%v", a, b)
+	}
+
+	m := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	views := NewMat(2, 2)
+	views.Sub(m.Slice(1, 1, 2, 2), m.Slice(0, 0, 2, 2))
+	expected = NewMat(2, 2, 4, 4, 4, 4)
+	if !views.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, views)
+	}
+}
+
+func TestMatrix_AddScaled(t *testing.T) {
+	tests := []struct {
+		s        int
+		expected *Matrix
+	}{
+		{0, NewMat(2, 2, 1, 0, 2, -1)},
+		{1, NewMat(2, 2, 4, 1, 2, 0)},
+		{-2, NewMat(2, 2, -5, -2, 2, -3)},
+	}
+	a := NewMat(2, 2, 1, 0, 2, -1)
+	b := NewMat(2, 2, 3, 1, 0, 1)
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.s), func(t *testing.T) {
+			actual := NewMat(2, 2, 7, 7, 7, 7)
+			actual.AddScaled(a, test.s, b)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected:\n%v\nbut found:\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_Scale(t *testing.T) {
+	a := NewMat(2, 2, 1, 0, -3, 4)
+	actual := NewMat(2, 2, 9, 9, 9, 9)
+	actual.Scale(a, -2)
+	expected := NewMat(2, 2, -2, 0, 6, -8)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	a.Scale(a, 3)
+	expected = NewMat(2, 2, 3, 0, -9, 12)
+	if !a.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, a)
+	}
+
+	a.Scale(a, 0)
+	if !a.Equals(NewMat(2, 2)) {
+		t.Fatalf("expected zero matrix but found:\n%v", a)
+	}
+}
+
+func TestMatrix_Scale_Views(t *testing.T) {
+	m := NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	m.Slice(0, 0, 2, 2).Scale(m.Slice(1, 1, 2, 2), 2)
+	expected := NewMat(3, 3, 10, 12, 3, 16, 18, 6, 7, 8, 9)
+	if !m.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+
+	m = NewMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	m.Slice(1, 1, 2, 2).Scale(m.Slice(0, 0, 2, 2), -1)
+	expected = NewMat(3, 3, 1, 2, 3, 4, -1, -2, 7, -4, -5)
+	if !m.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+}
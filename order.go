@@ -0,0 +1,310 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+)
+
+// OrderGF2 returns the multiplicative order of the matrix over GF(2), the smallest k > 0 with
+// A^k = I where entries are taken mod 2. The matrix must be square and invertible over GF(2).
+//
+// The characteristic polynomial is split into its squarefree parts and those into the degrees of
+// their irreducible factors. When the factors have degrees d1, d2, ... and none occurs more than e
+// times the order divides 2^t * lcm(2^d1-1, 2^d2-1, ...) where 2^t is the smallest power of two not
+// less than e. Starting from that bound each prime factor is removed for as long as the power,
+// taken as a BitMatrix, stays the identity. Only 2^d-1 for the degrees that occur are factored, so
+// a large irreducible factor whose 2^d-1 is hard to factor still makes this slow.
+func (mat *Matrix) OrderGF2() *big.Int {
+	if mat.rows != mat.cols {
+		panic(fmt.Sprintf("matrix must be square to find its order, got %dx%d", mat.rows, mat.cols))
+	}
+
+	n := mat.rows
+	if n == 0 {
+		return big.NewInt(1)
+	}
+
+	bm := NewBitMatFromMat(mat)
+	charPoly := bm.charPolyGF2()
+	if charPoly.Bit(0) == 0 {
+		panic("matrix is not invertible over GF(2)")
+	}
+
+	order := big.NewInt(1)
+	primes := map[string]*big.Int{}
+	degrees := map[int]bool{}
+	multiplicity := 0
+	for _, part := range squarefreeGF2(charPoly) {
+		if part.multiplicity > multiplicity {
+			multiplicity = part.multiplicity
+		}
+		for _, d := range distinctDegreesGF2(part.poly) {
+			degrees[d] = true
+		}
+	}
+
+	two := big.NewInt(2)
+	for e := 1; e < multiplicity; e *= 2 {
+		order.Mul(order, two)
+		primes[two.String()] = two
+	}
+
+	one := big.NewInt(1)
+	for d := 1; d <= n; d++ {
+		if !degrees[d] {
+			continue
+		}
+		mersenne := new(big.Int).Sub(new(big.Int).Lsh(one, uint(d)), one)
+		for _, p := range primeFactors(mersenne) {
+			primes[p.String()] = p
+		}
+		gcd := new(big.Int).GCD(nil, nil, order, mersenne)
+		order.Mul(order, new(big.Int).Quo(mersenne, gcd))
+	}
+
+	// strip the primes in a fixed order so the work done does not depend on map iteration
+	keys := make([]string, 0, len(primes))
+	for k := range primes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	identity := BitIdentity(n)
+	quotient, remainder := new(big.Int), new(big.Int)
+	for _, k := range keys {
+		p := primes[k]
+		for {
+			quotient.QuoRem(order, p, remainder)
+			if remainder.Sign() != 0 || !bm.pow(quotient).Equals(identity) {
+				break
+			}
+			order.Set(quotient)
+		}
+	}
+	return order
+}
+
+// charPolyGF2 returns the characteristic polynomial of the square matrix over GF(2) with bit i
+// holding the coefficient of x^i. Every unit vector not yet spanned starts a Krylov sequence
+// v, Av, A^2v, ... that runs until it is dependent on the space found so far. The dependency is a
+// polynomial p with p(A)v in that space, and the characteristic polynomial is the product of them.
+func (bm *BitMatrix) charPolyGF2() *big.Int {
+	type basisVector struct {
+		vec   []uint64
+		pivot int
+		poly  *big.Int // the combination of the current sequence, nil for earlier sequences
+	}
+	var basis []basisVector
+
+	charPoly := big.NewInt(1)
+	for j := 0; j < bm.rows; j++ {
+		for k := range basis {
+			basis[k].poly = nil
+		}
+
+		u := make([]uint64, bm.stride)
+		u[j/64] |= 1 << uint(j%64)
+		for k := 0; ; k++ {
+			r := append([]uint64(nil), u...)
+			poly := new(big.Int).SetBit(new(big.Int), k, 1)
+			for _, b := range basis {
+				if r[b.pivot/64]>>uint(b.pivot%64)&1 == 1 {
+					xorRow(r, b.vec)
+					if b.poly != nil {
+						poly.Xor(poly, b.poly)
+					}
+				}
+			}
+
+			pivot := -1
+			for w, x := range r {
+				if x != 0 {
+					pivot = 64*w + bits.TrailingZeros64(x)
+					break
+				}
+			}
+			if pivot < 0 {
+				charPoly = mulGF2(charPoly, poly)
+				break
+			}
+			basis = append(basis, basisVector{r, pivot, poly})
+			u = bm.mulVec(u)
+		}
+	}
+	return charPoly
+}
+
+// gf2Factor is a polynomial over GF(2) that divides another one multiplicity times.
+type gf2Factor struct {
+	poly         *big.Int
+	multiplicity int
+}
+
+// squarefreeGF2 returns the squarefree factorization of the polynomial f over GF(2), every
+// irreducible factor of a part divides f exactly as often as the part's multiplicity says.
+func squarefreeGF2(f *big.Int) []gf2Factor {
+	var parts []gf2Factor
+	c := f
+	if derivative := derivativeGF2(f); derivative.Sign() != 0 {
+		c = gcdGF2(f, derivative)
+		w, _ := quoRemGF2(f, c)
+		for i := 1; w.BitLen() > 1; i++ {
+			y := gcdGF2(w, c)
+			if z, _ := quoRemGF2(w, y); z.BitLen() > 1 {
+				parts = append(parts, gf2Factor{z, i})
+			}
+			w = y
+			c, _ = quoRemGF2(c, y)
+		}
+	}
+
+	// what is left is a square, every exponent in it is even
+	if c.BitLen() > 1 {
+		root := new(big.Int)
+		for i := 0; 2*i < c.BitLen(); i++ {
+			root.SetBit(root, i, c.Bit(2*i))
+		}
+		for _, part := range squarefreeGF2(root) {
+			parts = append(parts, gf2Factor{part.poly, 2 * part.multiplicity})
+		}
+	}
+	return parts
+}
+
+// distinctDegreesGF2 returns the distinct degrees of the irreducible factors of the squarefree
+// polynomial f over GF(2) in increasing order. The factors of degree i are the ones shared with
+// x^(2^i) - x.
+func distinctDegreesGF2(f *big.Int) []int {
+	var degrees []int
+	x := big.NewInt(2)
+	h := x
+	for i := 1; 2*i <= f.BitLen()-1; i++ {
+		_, h = quoRemGF2(mulGF2(h, h), f)
+		if g := gcdGF2(f, new(big.Int).Xor(h, x)); g.BitLen() > 1 {
+			degrees = append(degrees, i)
+			f, _ = quoRemGF2(f, g)
+			_, h = quoRemGF2(h, f)
+		}
+	}
+	if f.BitLen() > 1 {
+		degrees = append(degrees, f.BitLen()-1)
+	}
+	return degrees
+}
+
+// mulGF2 returns the product of the polynomials a and b over GF(2).
+func mulGF2(a, b *big.Int) *big.Int {
+	product, shifted := new(big.Int), new(big.Int)
+	for i := 0; i < a.BitLen(); i++ {
+		if a.Bit(i) == 1 {
+			product.Xor(product, shifted.Lsh(b, uint(i)))
+		}
+	}
+	return product
+}
+
+// quoRemGF2 returns the quotient and remainder of the polynomial a divided by the nonzero b over
+// GF(2).
+func quoRemGF2(a, b *big.Int) (*big.Int, *big.Int) {
+	quotient, remainder, shifted := new(big.Int), new(big.Int).Set(a), new(big.Int)
+	for remainder.BitLen() >= b.BitLen() {
+		shift := remainder.BitLen() - b.BitLen()
+		quotient.SetBit(quotient, shift, 1)
+		remainder.Xor(remainder, shifted.Lsh(b, uint(shift)))
+	}
+	return quotient, remainder
+}
+
+// gcdGF2 returns the greatest common divisor of the polynomials a and b over GF(2).
+func gcdGF2(a, b *big.Int) *big.Int {
+	for b.Sign() != 0 {
+		_, r := quoRemGF2(a, b)
+		a, b = b, r
+	}
+	return a
+}
+
+// derivativeGF2 returns the formal derivative of the polynomial f over GF(2).
+func derivativeGF2(f *big.Int) *big.Int {
+	derivative := new(big.Int)
+	for i := 1; i < f.BitLen(); i += 2 {
+		derivative.SetBit(derivative, i-1, f.Bit(i))
+	}
+	return derivative
+}
+
+// primeFactors returns the distinct prime factors of n > 0 in increasing order.
+func primeFactors(n *big.Int) []*big.Int {
+	var factors []*big.Int
+	remaining := []*big.Int{new(big.Int).Set(n)}
+
+	// small primes are cheaper to strip by trial division than with rho
+	for _, p := range []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		bp := big.NewInt(p)
+		m := remaining[0]
+		found := false
+		for new(big.Int).Rem(m, bp).Sign() == 0 {
+			m.Quo(m, bp)
+			found = true
+		}
+		if found {
+			factors = append(factors, bp)
+		}
+	}
+
+	for len(remaining) > 0 {
+		m := remaining[len(remaining)-1]
+		remaining = remaining[:len(remaining)-1]
+		switch {
+		case m.Cmp(big.NewInt(1)) == 0:
+		case m.ProbablyPrime(20):
+			factors = append(factors, m)
+		default:
+			d := pollardRho(m)
+			remaining = append(remaining, d, new(big.Int).Quo(m, d))
+		}
+	}
+
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Cmp(factors[j]) < 0 })
+	distinct := factors[:0]
+	for _, f := range factors {
+		if len(distinct) == 0 || distinct[len(distinct)-1].Cmp(f) != 0 {
+			distinct = append(distinct, f)
+		}
+	}
+	return distinct
+}
+
+// pollardRho returns a nontrivial factor of the odd composite n using Brent's variant of Pollard's
+// rho method.
+func pollardRho(n *big.Int) *big.Int {
+	one := big.NewInt(1)
+	for c := int64(1); ; c++ {
+		bc := big.NewInt(c)
+		f := func(x *big.Int) *big.Int {
+			x.Mul(x, x)
+			x.Add(x, bc)
+			return x.Mod(x, n)
+		}
+
+		x, y := big.NewInt(2), big.NewInt(2)
+		d := big.NewInt(1)
+		diff := new(big.Int)
+		power, lam := 1, 0
+		for d.Cmp(one) == 0 {
+			if power == lam {
+				x.Set(y)
+				power *= 2
+				lam = 0
+			}
+			f(y)
+			lam++
+			d.GCD(nil, nil, diff.Abs(diff.Sub(x, y)), n)
+		}
+		if d.Cmp(n) != 0 {
+			return d
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package intmat
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMatrix_PowBig(t *testing.T) {
+	m := NewMat(2, 2, 1, 2, 3, 4)
+	for k := 0; k < 6; k++ {
+		expected := m.Pow(k)
+		actual := m.PowBig(big.NewInt(int64(k)))
+		if !actual.Equals(expected) {
+			t.Fatalf("k=%v expected:\n%v\nbut found:\n%v", k, expected, actual)
+		}
+	}
+
+	// a 3-cycle has P^3 = I and 2^200 = 1 mod 3
+	cycle := NewMat(3, 3, 0, 1, 0, 0, 0, 1, 1, 0, 0)
+	actual := cycle.PowBig(new(big.Int).Lsh(big.NewInt(1), 200))
+	if !actual.Equals(cycle) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", cycle, actual)
+	}
+
+	bigCycle := NewBigIntMat(3, 3, intsToBigInts([]int{0, 1, 0, 0, 0, 1, 1, 0, 0})...)
+	bigActual := bigCycle.PowBig(new(big.Int).Lsh(big.NewInt(1), 200))
+	if !bigActual.Equals(bigCycle) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", bigCycle, bigActual)
+	}
+}
+
+func TestMatrix_OrderGF2(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        *Matrix
+		expected int64
+	}{
+		{"identity", Identity(4), 1},
+		{"transvection", NewMat(2, 2, 1, 1, 0, 1), 2},
+		{"jordan_3", NewMat(3, 3, 1, 1, 0, 0, 1, 1, 0, 0, 1), 4},
+		{"permutation_6", NewPermutation(1, 0, 3, 4, 2).Matrix(), 6},
+		{"odd_entries", NewMat(2, 2, 3, 5, 0, -1), 2},
+		// companion matrices of primitive polynomials have order 2^d-1
+		{"primitive_3", Companion([]int{0, 1, 1}), 7},
+		{"primitive_4", Companion([]int{0, 0, 1, 1}), 15},
+		{"primitive_16", Companion([]int{0, 1, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}), 65535},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.m.OrderGF2()
+			if actual.Cmp(big.NewInt(test.expected)) != 0 {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatrix_OrderGF2_Large(t *testing.T) {
+	// x^64 + x^4 + x^3 + x + 1 is primitive so its companion matrix has order 2^64-1
+	coeffs := make([]int, 64)
+	coeffs[59], coeffs[60], coeffs[62], coeffs[63] = 1, 1, 1, 1
+	one := big.NewInt(1)
+	mersenne := new(big.Int).Sub(new(big.Int).Lsh(one, 64), one)
+
+	// a single Jordan block of size 64 for the eigenvalue 1
+	jordan := Identity(64)
+	for i := 0; i+1 < 64; i++ {
+		jordan.Set(i, i+1, 1)
+	}
+
+	tests := []struct {
+		name     string
+		m        *Matrix
+		expected *big.Int
+	}{
+		{"identity_64", Identity(64), one},
+		{"primitive_64", Companion(coeffs), mersenne},
+		{"jordan_64", jordan, big.NewInt(64)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := time.Now()
+			actual := test.m.OrderGF2()
+			if actual.Cmp(test.expected) != 0 {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Fatalf("expected the order within a second but took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestMatrix_OrderGF2_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n := 1 + rng.Intn(8)
+		m := NewMat(n, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				m.Set(i, j, rng.Intn(2))
+			}
+		}
+		bm := NewBitMatFromMat(m)
+		if _, ok := bm.Inverse(); !ok {
+			continue
+		}
+
+		// walk the powers until the identity comes back
+		expected := int64(1)
+		power := BitCopy(bm)
+		for !power.Equals(BitIdentity(n)) {
+			next := NewBitMat(n, n)
+			next.Mul(power, bm)
+			power = next
+			expected++
+		}
+
+		if actual := m.OrderGF2(); actual.Cmp(big.NewInt(expected)) != 0 {
+			t.Fatalf("expected %v but found %v for:\n%v", expected, actual, m)
+		}
+	}
+}
+
+func TestMatrix_OrderGF2_Singular(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	NewMat(2, 2, 1, 1, 1, 1).OrderGF2()
+}
+
+func TestPrimeFactors(t *testing.T) {
+	// 2^64-1 = 3 * 5 * 17 * 257 * 641 * 65537 * 6700417
+	n := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1))
+	var actual []int64
+	for _, p := range primeFactors(n) {
+		actual = append(actual, p.Int64())
+	}
+	expected := []int64{3, 5, 17, 257, 641, 65537, 6700417}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v but found %v", expected, actual)
+	}
+}
@@ -0,0 +1,128 @@
+package intmat
+
+import (
+	"fmt"
+)
+
+// Permutation maps index i to index p[i]. Applying a permutation to a matrix's rows moves row i
+// to row p[i], and likewise for columns and vector indices.
+type Permutation []int
+
+// NewPermutation creates a permutation from values, which must hold every index in
+// [0,len(values)) exactly once.
+func NewPermutation(values ...int) Permutation {
+	checkPermutation(values, len(values))
+
+	p := make(Permutation, len(values))
+	copy(p, values)
+	return p
+}
+
+// IdentityPermutation creates the permutation of size n that leaves every index in place.
+func IdentityPermutation(n int) Permutation {
+	p := make(Permutation, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// PermutationFromMatrix returns the permutation of the permutation matrix m, the inverse of
+// Permutation.Matrix. m must be square with exactly one 1 in each row and column.
+func PermutationFromMatrix(m *Matrix) Permutation {
+	if m == nil {
+		panic("permutation matrix was found to be nil")
+	}
+	if m.rows != m.cols {
+		panic(fmt.Sprintf("permutation matrix must be square, got %dx%d", m.rows, m.cols))
+	}
+
+	p := make(Permutation, m.cols)
+	for j := range p {
+		p[j] = -1
+	}
+	m.DoNonzero(func(i, j, value int) {
+		if value != 1 || p[j] >= 0 {
+			panic("matrix is not a permutation matrix")
+		}
+		p[j] = i
+	})
+	for _, i := range p {
+		if i < 0 {
+			panic("matrix is not a permutation matrix")
+		}
+	}
+	checkPermutation(p, len(p))
+
+	return p
+}
+
+// Len returns the number of indices the permutation acts on.
+func (p Permutation) Len() int {
+	return len(p)
+}
+
+// Inverse returns the permutation that undoes p.
+func (p Permutation) Inverse() Permutation {
+	inverse := make(Permutation, len(p))
+	for i, j := range p {
+		inverse[j] = i
+	}
+	return inverse
+}
+
+// Compose returns the permutation that applies q and then p, mapping i to p[q[i]].
+func (p Permutation) Compose(q Permutation) Permutation {
+	if len(p) != len(q) {
+		panic(fmt.Sprintf("permutation lengths do not match %v and %v", len(p), len(q)))
+	}
+
+	composed := make(Permutation, len(p))
+	for i, j := range q {
+		composed[i] = p[j]
+	}
+	return composed
+}
+
+// Equals returns true if q maps every index to the same place as p.
+func (p Permutation) Equals(q Permutation) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Matrix returns the permutation matrix P of p, with P[p[i]][i] = 1. Multiplying P*A moves row i
+// of A to row p[i] and A*P^T moves column j of A to column p[j].
+func (p Permutation) Matrix() *Matrix {
+	mat := NewMat(len(p), len(p))
+	for i, j := range p {
+		mat.set(j, i, 1)
+	}
+	return mat
+}
+
+// ApplyRows moves row i of m to row p[i].
+func (p Permutation) ApplyRows(m *Matrix) {
+	m.PermuteRows(p)
+}
+
+// ApplyCols moves column j of m to column p[j].
+func (p Permutation) ApplyCols(m *Matrix) {
+	m.PermuteCols(p)
+}
+
+// ApplyVec moves the value at index i of vec to index p[i].
+func (p Permutation) ApplyVec(vec *Vector) {
+	vec.mat.PermuteCols(p)
+}
+
+// ApplyTVec moves the value at index i of tvec to index p[i].
+func (p Permutation) ApplyTVec(tvec *TransposedVector) {
+	tvec.mat.PermuteRows(p)
+}
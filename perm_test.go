@@ -0,0 +1,161 @@
+package intmat
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestNewPermutation(t *testing.T) {
+	tests := []struct {
+		values []int
+		panic  bool
+	}{
+		{[]int{}, false},
+		{[]int{2, 0, 1}, false},
+		{[]int{0, 0, 1}, true},
+		{[]int{0, 3, 1}, true},
+		{[]int{-1, 0}, true},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if test.panic && r == nil {
+					t.Errorf("expected panic but did not get one")
+				}
+				if !test.panic && r != nil {
+					t.Errorf("did not expect panic but got: %v", r)
+				}
+			}()
+
+			p := NewPermutation(test.values...)
+			if !p.Equals(test.values) {
+				t.Fatalf("expected %v but found %v", test.values, p)
+			}
+		})
+	}
+}
+
+func TestPermutation_Inverse(t *testing.T) {
+	tests := []struct {
+		p, expected Permutation
+	}{
+		{IdentityPermutation(3), IdentityPermutation(3)},
+		{NewPermutation(1, 2, 0), NewPermutation(2, 0, 1)},
+		{NewPermutation(3, 0, 2, 1), NewPermutation(1, 3, 2, 0)},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := test.p.Inverse()
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+			if !test.p.Compose(actual).Equals(IdentityPermutation(test.p.Len())) {
+				t.Fatalf("expected p∘p⁻¹ to be the identity but found %v", test.p.Compose(actual))
+			}
+		})
+	}
+}
+
+func TestPermutation_Compose(t *testing.T) {
+	p := NewPermutation(1, 2, 0)
+	q := NewPermutation(0, 2, 1)
+
+	m := NewMat(3, 1, 1, 2, 3)
+	q.ApplyRows(m)
+	p.ApplyRows(m)
+
+	composed := NewMat(3, 1, 1, 2, 3)
+	p.Compose(q).ApplyRows(composed)
+
+	if !m.Equals(composed) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", m, composed)
+	}
+	if !p.Compose(q).Equals(NewPermutation(1, 0, 2)) {
+		t.Fatalf("expected %v but found %v", NewPermutation(1, 0, 2), p.Compose(q))
+	}
+}
+
+func TestPermutation_Matrix(t *testing.T) {
+	p := NewPermutation(2, 0, 1)
+	a := NewMat(3, 2, 1, 2, 3, 4, 5, 6)
+
+	expected := Copy(a)
+	p.ApplyRows(expected)
+
+	actual := NewMat(3, 2)
+	actual.Mul(p.Matrix(), a)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, actual)
+	}
+
+	if !PermutationFromMatrix(p.Matrix()).Equals(p) {
+		t.Fatalf("expected %v but found %v", p, PermutationFromMatrix(p.Matrix()))
+	}
+}
+
+func TestPermutationFromMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        *Matrix
+		expected Permutation
+		panic    bool
+	}{
+		{"identity", Identity(3), IdentityPermutation(3), false},
+		{"swap", NewMat(2, 2, 0, 1, 1, 0), NewPermutation(1, 0), false},
+		{"not_square", NewMat(2, 3, 1, 0, 0, 0, 1, 0), nil, true},
+		{"empty_column", NewMat(2, 2, 1, 1, 0, 0), nil, true},
+		{"not_one", NewMat(2, 2, 2, 0, 0, 1), nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if test.panic && r == nil {
+					t.Errorf("expected panic but did not get one")
+				}
+				if !test.panic && r != nil {
+					t.Errorf("did not expect panic but got: %v", r)
+				}
+			}()
+
+			actual := PermutationFromMatrix(test.m)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPermutation_ApplyCols(t *testing.T) {
+	p := NewPermutation(1, 2, 0)
+	m := NewMat(2, 3, 1, 2, 3, 4, 5, 6)
+	p.ApplyCols(m)
+
+	expected := NewMat(2, 3, 3, 1, 2, 6, 4, 5)
+	if !m.Equals(expected) {
+		t.Fatalf("expected \n%v\n but found \n%v\n", expected, m)
+	}
+}
+
+func TestPermutation_ApplyVec(t *testing.T) {
+	p := NewPermutation(1, 2, 0)
+	vec := NewVec(3, 1, 2, 3)
+	p.ApplyVec(vec)
+
+	expected := NewVec(3, 3, 1, 2)
+	if !vec.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, vec)
+	}
+}
+
+func TestPermutation_ApplyTVec(t *testing.T) {
+	p := NewPermutation(1, 2, 0)
+	tvec := NewTVec(3, 1, 2, 3)
+	p.ApplyTVec(tvec)
+
+	expected := NewTVec(3, 3, 1, 2)
+	if !tvec.Equals(expected) {
+		t.Fatalf("expected %v but found %v", expected, tvec)
+	}
+}
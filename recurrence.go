@@ -0,0 +1,118 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Companion returns the companion matrix of the linear recurrence
+//
+//	a[n] = coeffs[0]*a[n-1] + coeffs[1]*a[n-2] + ... + coeffs[d-1]*a[n-d]
+//
+// Multiplying the column (a[n-1], ..., a[n-d]) by it gives (a[n], ..., a[n-d+1]).
+func Companion(coeffs []int) *Matrix {
+	checkRecurrence(len(coeffs), len(coeffs))
+
+	d := len(coeffs)
+	mat := NewMat(d, d)
+	for j, c := range coeffs {
+		mat.set(0, j, c)
+	}
+	for i := 1; i < d; i++ {
+		mat.set(i, i-1, 1)
+	}
+	return mat
+}
+
+// BigIntCompanion returns the companion matrix of the linear recurrence with the given
+// coefficients, see Companion.
+func BigIntCompanion(coeffs []*big.Int) *BigIntMatrix {
+	checkRecurrence(len(coeffs), len(coeffs))
+
+	d := len(coeffs)
+	mat := NewBigIntMat(d, d)
+	for j, c := range coeffs {
+		if c != nil {
+			mat.set(0, j, new(big.Int).Set(c))
+		}
+	}
+	for i := 1; i < d; i++ {
+		mat.set(i, i-1, big.NewInt(1))
+	}
+	return mat
+}
+
+// RecurrenceTerm returns a[n] mod m for the linear recurrence with the given coefficients (see
+// Companion) and initial terms a[0], ..., a[d-1]. The result is in [0,m).
+func RecurrenceTerm(coeffs, initial []int, n *big.Int, m int) int {
+	checkRecurrence(len(coeffs), len(initial))
+	if n.Sign() < 0 {
+		panic(fmt.Sprintf("term index n must be non-negative, got %v", n))
+	}
+	if m <= 0 {
+		panic(fmt.Sprintf("modulus m must be positive, got %v", m))
+	}
+
+	d := len(coeffs)
+	if n.IsInt64() && n.Int64() < int64(d) {
+		return (initial[n.Int64()]%m + m) % m
+	}
+
+	// C^(n-d+1) maps (a[d-1], ..., a[0]) to (a[n], ..., a[n-d+1])
+	k := new(big.Int).Sub(n, big.NewInt(int64(d-1)))
+	p := Companion(coeffs).PowMod(k, m)
+
+	value := 0
+	for j := 0; j < d; j++ {
+		a := (initial[d-1-j]%m + m) % m
+		value = addMod(value, mulMod(p.at(0, j), a, m), m)
+	}
+	return value
+}
+
+// BigIntRecurrenceTerm returns a[n] mod m for the linear recurrence with the given coefficients
+// (see Companion) and initial terms a[0], ..., a[d-1]. The result is in [0,m).
+func BigIntRecurrenceTerm(coeffs, initial []*big.Int, n, m *big.Int) *big.Int {
+	checkRecurrence(len(coeffs), len(initial))
+	if n.Sign() < 0 {
+		panic(fmt.Sprintf("term index n must be non-negative, got %v", n))
+	}
+	if m.Sign() <= 0 {
+		panic(fmt.Sprintf("modulus m must be positive, got %v", m))
+	}
+
+	d := len(coeffs)
+	if n.IsInt64() && n.Int64() < int64(d) {
+		return bigIntMod(initial[n.Int64()], m)
+	}
+
+	k := new(big.Int).Sub(n, big.NewInt(int64(d-1)))
+	p := BigIntCompanion(coeffs).PowMod(k, m)
+
+	value := big.NewInt(0)
+	for j := 0; j < d; j++ {
+		v := p.at(0, j)
+		if v == nil || initial[d-1-j] == nil {
+			continue
+		}
+		value.Add(value, new(big.Int).Mul(v, initial[d-1-j]))
+	}
+	return value.Mod(value, m)
+}
+
+func checkRecurrence(coeffs, initial int) {
+	if coeffs == 0 {
+		panic("recurrence must have at least one coefficient")
+	}
+	if coeffs != initial {
+		panic(fmt.Sprintf("recurrence of order %v needs %v initial terms, got %v", coeffs, coeffs, initial))
+	}
+}
+
+// bigIntMod returns v mod m in [0,m) treating nil as zero.
+func bigIntMod(v, m *big.Int) *big.Int {
+	if v == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Mod(v, m)
+}
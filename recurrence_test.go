@@ -0,0 +1,59 @@
+package intmat
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestCompanion(t *testing.T) {
+	actual := Companion([]int{1, 2, 3})
+	expected := NewMat(3, 3,
+		1, 2, 3,
+		1, 0, 0,
+		0, 1, 0,
+	)
+	if !actual.Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	bigActual := BigIntCompanion(intsToBigInts([]int{1, 2, 3}))
+	bigExpected := NewBigIntMat(3, 3, intsToBigInts([]int{1, 2, 3, 1, 0, 0, 0, 1, 0})...)
+	if !bigActual.Equals(bigExpected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", bigExpected, bigActual)
+	}
+}
+
+func TestRecurrenceTerm(t *testing.T) {
+	// Pisano period of 10 is 60
+	huge := new(big.Int).Mul(big.NewInt(60), new(big.Int).Lsh(big.NewInt(1), 70))
+	tests := []struct {
+		coeffs, initial []int
+		n               *big.Int
+		m               int
+		expected        int
+	}{
+		{[]int{1, 1}, []int{0, 1}, big.NewInt(0), 1000, 0},
+		{[]int{1, 1}, []int{0, 1}, big.NewInt(1), 1000, 1},
+		{[]int{1, 1}, []int{0, 1}, big.NewInt(10), 1000, 55},
+		{[]int{1, 1}, []int{0, 1}, big.NewInt(20), 1000, 765},
+		{[]int{1, 1}, []int{0, 1}, new(big.Int).Add(huge, big.NewInt(7)), 10, 3},
+		{[]int{2}, []int{3}, big.NewInt(5), 1000, 96},
+		{[]int{0, 0, 1}, []int{1, 2, 3}, big.NewInt(7), 100, 2},
+		{[]int{-1}, []int{1}, big.NewInt(3), 5, 4},
+	}
+
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			actual := RecurrenceTerm(test.coeffs, test.initial, test.n, test.m)
+			if actual != test.expected {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+
+			bigActual := BigIntRecurrenceTerm(intsToBigInts(test.coeffs), intsToBigInts(test.initial), test.n, big.NewInt(int64(test.m)))
+			if bigActual.Cmp(big.NewInt(int64(test.expected))) != 0 {
+				t.Fatalf("expected %v but found %v", test.expected, bigActual)
+			}
+		})
+	}
+}
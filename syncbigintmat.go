@@ -0,0 +1,319 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// SyncBigIntMatrix wraps a BigIntMatrix with a read/write lock so it can be read and written from several goroutines.
+// Views made with Slice, T, Row and Column share the lock of the matrix they came from, so a write
+// through any view excludes readers of every other view of the same storage.
+//
+// The methods mirror those of BigIntMatrix with one difference: there are no synchronized vector types, so
+// Row and Column return 1 x cols and rows x 1 SyncBigIntMatrix views and SetRow and SetColumn take such views.
+type SyncBigIntMatrix struct {
+	lock *syncLock
+	mat  *BigIntMatrix
+}
+
+// NewSyncBigIntMat returns a new synchronized matrix, see NewBigIntMat.
+func NewSyncBigIntMat(rows, cols int, values ...*big.Int) *SyncBigIntMatrix {
+	return &SyncBigIntMatrix{lock: newSyncLock(), mat: NewBigIntMat(rows, cols, values...)}
+}
+
+// NewSyncBigIntMatFrom wraps m in a synchronized matrix. The matrix m, and any view sharing its
+// storage, must not be used directly afterwards.
+func NewSyncBigIntMatFrom(m *BigIntMatrix) *SyncBigIntMatrix {
+	return &SyncBigIntMatrix{lock: newSyncLock(), mat: m}
+}
+
+// Unwrap returns a copy of the values as a plain BigIntMatrix.
+func (s *SyncBigIntMatrix) Unwrap() *BigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return BigIntCopy(s.mat)
+}
+
+// Snapshot returns a synchronized copy-on-write snapshot of the matrix with a lock of its own, see
+// BigIntMatrix.Snapshot.
+func (s *SyncBigIntMatrix) Snapshot() *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.Snapshot())
+}
+
+func (s *SyncBigIntMatrix) view(m *BigIntMatrix) *SyncBigIntMatrix {
+	return &SyncBigIntMatrix{lock: s.lock, mat: m}
+}
+
+// freshSyncBigIntMatrix wraps a newly made matrix with its own lock.
+func freshSyncBigIntMatrix(m *BigIntMatrix) *SyncBigIntMatrix {
+	return &SyncBigIntMatrix{lock: newSyncLock(), mat: m}
+}
+
+func (s *SyncBigIntMatrix) MarshalJSON() ([]byte, error) {
+	defer lockSync(nil, s.lock)()
+	return s.mat.MarshalJSON()
+}
+
+func (s *SyncBigIntMatrix) UnmarshalJSON(bytes []byte) error {
+	if s.lock == nil {
+		s.lock = newSyncLock()
+	}
+	defer lockSync(s.lock)()
+	if s.mat == nil {
+		s.mat = &BigIntMatrix{}
+	}
+	return s.mat.UnmarshalJSON(bytes)
+}
+
+// Slice returns a view of the matrix sharing its storage and lock, see BigIntMatrix.Slice.
+func (s *SyncBigIntMatrix) Slice(i, j, rows, cols int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return s.view(s.mat.Slice(i, j, rows, cols))
+}
+
+// T returns the transposed view of the matrix sharing its storage and lock.
+func (s *SyncBigIntMatrix) T() *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return s.view(s.mat.T())
+}
+
+// Row returns row i as a 1 x cols view sharing the storage and lock.
+func (s *SyncBigIntMatrix) Row(i int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	s.mat.checkRowBounds(i)
+	return s.view(s.mat.Slice(i, 0, 1, s.mat.cols))
+}
+
+// Column returns column j as a rows x 1 view sharing the storage and lock.
+func (s *SyncBigIntMatrix) Column(j int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	s.mat.checkColBounds(j)
+	return s.view(s.mat.Slice(0, j, s.mat.rows, 1))
+}
+
+// Dims returns the dimensions of the matrix.
+func (s *SyncBigIntMatrix) Dims() (int, int) {
+	defer lockSync(nil, s.lock)()
+	return s.mat.Dims()
+}
+
+// At returns the value at row index i and column index j.
+func (s *SyncBigIntMatrix) At(i, j int) *big.Int {
+	defer lockSync(nil, s.lock)()
+	return new(big.Int).Set(s.mat.At(i, j))
+}
+
+// Set sets the value at row index i and column index j to value.
+func (s *SyncBigIntMatrix) Set(i, j int, value *big.Int) {
+	defer lockSync(s.lock)()
+	s.mat.Set(i, j, value)
+}
+
+// SetNoCopy sets the value at row index i and column index j to value without copying it, see
+// BigIntMatrix.SetNoCopy.
+func (s *SyncBigIntMatrix) SetNoCopy(i, j int, value *big.Int) {
+	defer lockSync(s.lock)()
+	s.mat.SetNoCopy(i, j, value)
+}
+
+// SetRow sets the values in row i to the values of row, a 1 x cols matrix such as a Row view.
+func (s *SyncBigIntMatrix) SetRow(i int, row *SyncBigIntMatrix) {
+	defer lockSync(s.lock, row.lock)()
+	if row.mat.rows != 1 {
+		panic(fmt.Sprintf("row must have a single row, got %vx%v", row.mat.rows, row.mat.cols))
+	}
+	// the row may be a view of this matrix
+	s.mat.SetRow(i, &BigIntVector{mat: BigIntCopy(row.mat)})
+}
+
+// SetColumn sets the values in column j to the values of column, a rows x 1 matrix such as a Column
+// view.
+func (s *SyncBigIntMatrix) SetColumn(j int, column *SyncBigIntMatrix) {
+	defer lockSync(s.lock, column.lock)()
+	if column.mat.cols != 1 {
+		panic(fmt.Sprintf("column must have a single column, got %vx%v", column.mat.rows, column.mat.cols))
+	}
+	// the column may be a view of this matrix
+	s.mat.SetColumn(j, &TransposedBigIntVector{mat: BigIntCopy(column.mat)})
+}
+
+// Zeroize sets all the values of the matrix to zero.
+func (s *SyncBigIntMatrix) Zeroize() {
+	defer lockSync(s.lock)()
+	s.mat.Zeroize()
+}
+
+// ZeroizeRange sets the values in the given range to zero.
+func (s *SyncBigIntMatrix) ZeroizeRange(i, j, rows, cols int) {
+	defer lockSync(s.lock)()
+	s.mat.ZeroizeRange(i, j, rows, cols)
+}
+
+// SetMatrix replaces the values of this matrix with the values of a starting at (iOffset,jOffset).
+func (s *SyncBigIntMatrix) SetMatrix(a *SyncBigIntMatrix, iOffset, jOffset int) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.SetMatrix(a.mat, iOffset, jOffset)
+}
+
+// Negate negates every value in the matrix.
+func (s *SyncBigIntMatrix) Negate() {
+	defer lockSync(s.lock)()
+	s.mat.Negate()
+}
+
+// SwapRows swaps rows i and k.
+func (s *SyncBigIntMatrix) SwapRows(i, k int) {
+	defer lockSync(s.lock)()
+	s.mat.SwapRows(i, k)
+}
+
+// SwapCols swaps columns j and k.
+func (s *SyncBigIntMatrix) SwapCols(j, k int) {
+	defer lockSync(s.lock)()
+	s.mat.SwapCols(j, k)
+}
+
+// PermuteRows moves row i to row perm[i].
+func (s *SyncBigIntMatrix) PermuteRows(perm []int) {
+	defer lockSync(s.lock)()
+	s.mat.PermuteRows(perm)
+}
+
+// PermuteCols moves column j to column perm[j].
+func (s *SyncBigIntMatrix) PermuteCols(perm []int) {
+	defer lockSync(s.lock)()
+	s.mat.PermuteCols(perm)
+}
+
+// RemoveRows returns a new matrix without the given rows.
+func (s *SyncBigIntMatrix) RemoveRows(rows ...int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.RemoveRows(rows...))
+}
+
+// RemoveCols returns a new matrix without the given columns.
+func (s *SyncBigIntMatrix) RemoveCols(cols ...int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.RemoveCols(cols...))
+}
+
+// InsertZeroRows returns a new matrix with count zero rows inserted before row i.
+func (s *SyncBigIntMatrix) InsertZeroRows(i, count int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.InsertZeroRows(i, count))
+}
+
+// InsertZeroCols returns a new matrix with count zero columns inserted before column j.
+func (s *SyncBigIntMatrix) InsertZeroCols(j, count int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.InsertZeroCols(j, count))
+}
+
+// Pow returns the matrix raised to the power of k as a new matrix.
+func (s *SyncBigIntMatrix) Pow(k int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.Pow(k))
+}
+
+// PowBig returns the matrix raised to the power of k as a new matrix.
+func (s *SyncBigIntMatrix) PowBig(k *big.Int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.PowBig(k))
+}
+
+// PowMod returns the matrix raised to the power of k modulo m as a new matrix.
+func (s *SyncBigIntMatrix) PowMod(k *big.Int, m *big.Int) *SyncBigIntMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncBigIntMatrix(s.mat.PowMod(k, m))
+}
+
+// Mul stores the product of a and b in this matrix.
+func (s *SyncBigIntMatrix) Mul(a, b *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Mul(a.mat, b.mat)
+}
+
+// MulParallel stores the product of a and b in this matrix using workers goroutines.
+func (s *SyncBigIntMatrix) MulParallel(a, b *SyncBigIntMatrix, workers int) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.MulParallel(a.mat, b.mat, workers)
+}
+
+// MulDense stores the product of a and b in this matrix treating both as dense, see
+// BigIntMatrix.MulDense.
+func (s *SyncBigIntMatrix) MulDense(a, b *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.MulDense(a.mat, b.mat)
+}
+
+// Add stores the addition of a and b in this matrix.
+func (s *SyncBigIntMatrix) Add(a, b *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Add(a.mat, b.mat)
+}
+
+// Sub stores the subtraction a-b in this matrix.
+func (s *SyncBigIntMatrix) Sub(a, b *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Sub(a.mat, b.mat)
+}
+
+// MulElem stores the elementwise product of a and b in this matrix.
+func (s *SyncBigIntMatrix) MulElem(a, b *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.MulElem(a.mat, b.mat)
+}
+
+// Div stores the elementwise quotient a/b rounded as given by mode in this matrix.
+func (s *SyncBigIntMatrix) Div(a, b *SyncBigIntMatrix, mode Rounding) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Div(a.mat, b.mat, mode)
+}
+
+// Quo stores the elementwise quotient a/b truncated toward zero in this matrix.
+func (s *SyncBigIntMatrix) Quo(a, b *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Quo(a.mat, b.mat)
+}
+
+// Scale stores a with every value multiplied by k in this matrix.
+func (s *SyncBigIntMatrix) Scale(a *SyncBigIntMatrix, k *big.Int) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.Scale(a.mat, k)
+}
+
+// AddScalar stores a with k added to every value in this matrix.
+func (s *SyncBigIntMatrix) AddScalar(a *SyncBigIntMatrix, k *big.Int) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.AddScalar(a.mat, k)
+}
+
+// Mod stores a with every value reduced into [0,m) in this matrix.
+func (s *SyncBigIntMatrix) Mod(a *SyncBigIntMatrix, m *big.Int) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.Mod(a.mat, m)
+}
+
+// Abs stores the absolute values of a in this matrix.
+func (s *SyncBigIntMatrix) Abs(a *SyncBigIntMatrix) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.Abs(a.mat)
+}
+
+// Equals return true if m has the same shape and values as this matrix.
+func (s *SyncBigIntMatrix) Equals(m *SyncBigIntMatrix) bool {
+	defer lockSync(nil, s.lock, m.lock)()
+	return s.mat.Equals(m.mat)
+}
+
+// DoNonzero calls fn for every nonzero value, see BigIntMatrix.DoNonzero. The read lock is held during the
+// calls so fn must not write to the matrix or any view of it.
+func (s *SyncBigIntMatrix) DoNonzero(fn func(i, j int, value *big.Int)) {
+	defer lockSync(nil, s.lock)()
+	s.mat.DoNonzero(fn)
+}
+
+func (s *SyncBigIntMatrix) String() string {
+	defer lockSync(nil, s.lock)()
+	return s.mat.String()
+}
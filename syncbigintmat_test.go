@@ -0,0 +1,136 @@
+package intmat
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestSyncBigIntMatrix_ConcurrentViews(t *testing.T) {
+	m := NewSyncBigIntMat(6, 6)
+	views := []*SyncBigIntMatrix{m, m.T(), m.Slice(1, 1, 4, 4), m.Row(2), m.Column(4)}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < 200; k++ {
+				m.Set((w+k)%6, k%6, big.NewInt(int64(k)))
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < 200; k++ {
+				v := views[(w+k)%len(views)]
+				rows, cols := v.Dims()
+				v.At(k%rows, k%cols)
+				_ = v.String()
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+func TestSyncBigIntMatrix_Ownership(t *testing.T) {
+	m := NewSyncBigIntMat(2, 2)
+	value := big.NewInt(5)
+	m.Set(0, 1, value)
+	value.SetInt64(7)
+	if m.At(0, 1).Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("Set must copy its value")
+	}
+
+	m.At(0, 1).SetInt64(9)
+	if m.At(0, 1).Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("At must return a copy")
+	}
+
+	plain := m.Unwrap()
+	m.Negate()
+	if plain.At(0, 1).Cmp(big.NewInt(5)) != 0 {
+		t.Fatalf("Unwrap must not share values")
+	}
+
+	m.Set(0, 1, nil)
+	if m.At(0, 1).Sign() != 0 {
+		t.Fatalf("expected zero")
+	}
+}
+
+func TestSyncBigIntMatrix_SetRowColumn(t *testing.T) {
+	m := NewSyncBigIntMat(2, 2, intsToBigInts([]int{1, 2, 3, 4})...)
+	other := NewSyncBigIntMat(2, 1, intsToBigInts([]int{5, 6})...)
+
+	m.SetColumn(0, other.Column(0))
+	m.SetRow(1, m.Row(0))
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{5, 2, 5, 2})...)
+	if !m.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+}
+
+func TestSyncBigIntMatrix_Snapshot(t *testing.T) {
+	m := NewSyncBigIntMat(2, 2, intsToBigInts([]int{1, 2, 3, 4})...)
+	snapshot := m.Snapshot()
+	m.Negate()
+	snapshot.Set(1, 1, big.NewInt(8))
+
+	expected := NewBigIntMat(2, 2, intsToBigInts([]int{1, 2, 3, 8})...)
+	if !snapshot.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, snapshot)
+	}
+	expected = NewBigIntMat(2, 2, intsToBigInts([]int{-1, -2, -3, -4})...)
+	if !m.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+}
+
+func TestSyncBigIntMatrix_MulDense(t *testing.T) {
+	a := NewSyncBigIntMat(4, 4, intsToBigInts([]int{1, 2, 0, 1, 3, 1, 2, 0, 0, 4, 1, 2, 1, 0, 3, 1})...)
+	b := NewSyncBigIntMat(4, 4, intsToBigInts([]int{2, 0, 1, 1, 1, 3, 0, 2, 4, 1, 1, 0, 0, 2, 3, 1})...)
+	ab, ba := NewSyncBigIntMat(4, 4), NewSyncBigIntMat(4, 4)
+
+	// the two products lock their operands in opposite argument orders while a and b are written
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				ab.MulDense(a, b)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				ba.MulDense(b, a)
+			}
+		}()
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < 100; k++ {
+				a.Set(w, k%4, big.NewInt(int64(k%3)))
+				b.Set(k%4, w, big.NewInt(int64(k%5)))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	ab.MulDense(a, b)
+	expected := NewBigIntMat(4, 4)
+	expected.MulDense(a.Unwrap(), b.Unwrap())
+	if !ab.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, ab)
+	}
+
+	// operands that are views of one matrix share its lock, the product must not deadlock
+	actual := NewSyncBigIntMat(2, 2)
+	actual.MulDense(a.Slice(0, 0, 2, 4), a.Slice(0, 0, 4, 2))
+	plain := a.Unwrap()
+	expected = NewBigIntMat(2, 2)
+	expected.MulDense(plain.Slice(0, 0, 2, 4), plain.Slice(0, 0, 4, 2))
+	if !actual.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+}
@@ -0,0 +1,400 @@
+package intmat
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// syncLock is the lock shared by a synchronized matrix and every view derived from it.
+type syncLock struct {
+	sync.RWMutex
+	id uint64
+}
+
+var syncLockIDs uint64
+
+func newSyncLock() *syncLock {
+	return &syncLock{id: atomic.AddUint64(&syncLockIDs, 1)}
+}
+
+// lockSync takes write for writing, when not nil, and every lock in reads for reading, then returns
+// the function releasing them. Each distinct lock is taken once, as a writer if it is write, and
+// locks are taken in id order so operations over several matrices can't deadlock each other.
+func lockSync(write *syncLock, reads ...*syncLock) func() {
+	locks := make([]*syncLock, 0, len(reads)+1)
+	seen := make(map[*syncLock]bool, len(reads)+1)
+	for _, l := range append([]*syncLock{write}, reads...) {
+		if l != nil && !seen[l] {
+			seen[l] = true
+			locks = append(locks, l)
+		}
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].id < locks[j].id })
+
+	for _, l := range locks {
+		if l == write {
+			l.Lock()
+		} else {
+			l.RLock()
+		}
+	}
+	return func() {
+		for k := len(locks) - 1; k >= 0; k-- {
+			if locks[k] == write {
+				locks[k].Unlock()
+			} else {
+				locks[k].RUnlock()
+			}
+		}
+	}
+}
+
+// SyncMatrix wraps a Matrix with a read/write lock so it can be read and written from several goroutines.
+// Views made with Slice, T, Row and Column share the lock of the matrix they came from, so a write
+// through any view excludes readers of every other view of the same storage.
+//
+// The methods mirror those of Matrix with one difference: there are no synchronized vector types, so
+// Row and Column return 1 x cols and rows x 1 SyncMatrix views and SetRow and SetColumn take such views.
+type SyncMatrix struct {
+	lock *syncLock
+	mat  *Matrix
+}
+
+// NewSyncMat returns a new synchronized matrix, see NewMat.
+func NewSyncMat(rows, cols int, values ...int) *SyncMatrix {
+	return &SyncMatrix{lock: newSyncLock(), mat: NewMat(rows, cols, values...)}
+}
+
+// NewSyncMatFrom wraps m in a synchronized matrix. The matrix m, and any view sharing its
+// storage, must not be used directly afterwards.
+func NewSyncMatFrom(m *Matrix) *SyncMatrix {
+	return &SyncMatrix{lock: newSyncLock(), mat: m}
+}
+
+// Unwrap returns a copy of the values as a plain Matrix.
+func (s *SyncMatrix) Unwrap() *Matrix {
+	defer lockSync(nil, s.lock)()
+	return Copy(s.mat)
+}
+
+// Snapshot returns a synchronized copy-on-write snapshot of the matrix with a lock of its own, see
+// Matrix.Snapshot.
+func (s *SyncMatrix) Snapshot() *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.Snapshot())
+}
+
+func (s *SyncMatrix) view(m *Matrix) *SyncMatrix {
+	return &SyncMatrix{lock: s.lock, mat: m}
+}
+
+// freshSyncMatrix wraps a newly made matrix with its own lock.
+func freshSyncMatrix(m *Matrix) *SyncMatrix {
+	return &SyncMatrix{lock: newSyncLock(), mat: m}
+}
+
+func (s *SyncMatrix) MarshalJSON() ([]byte, error) {
+	defer lockSync(nil, s.lock)()
+	return s.mat.MarshalJSON()
+}
+
+func (s *SyncMatrix) UnmarshalJSON(bytes []byte) error {
+	if s.lock == nil {
+		s.lock = newSyncLock()
+	}
+	defer lockSync(s.lock)()
+	if s.mat == nil {
+		s.mat = &Matrix{}
+	}
+	return s.mat.UnmarshalJSON(bytes)
+}
+
+// Slice returns a view of the matrix sharing its storage and lock, see Matrix.Slice.
+func (s *SyncMatrix) Slice(i, j, rows, cols int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return s.view(s.mat.Slice(i, j, rows, cols))
+}
+
+// T returns the transposed view of the matrix sharing its storage and lock.
+func (s *SyncMatrix) T() *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return s.view(s.mat.T())
+}
+
+// Row returns row i as a 1 x cols view sharing the storage and lock.
+func (s *SyncMatrix) Row(i int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	s.mat.checkRowBounds(i)
+	return s.view(s.mat.Slice(i, 0, 1, s.mat.cols))
+}
+
+// Column returns column j as a rows x 1 view sharing the storage and lock.
+func (s *SyncMatrix) Column(j int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	s.mat.checkColBounds(j)
+	return s.view(s.mat.Slice(0, j, s.mat.rows, 1))
+}
+
+// Dims returns the dimensions of the matrix.
+func (s *SyncMatrix) Dims() (int, int) {
+	defer lockSync(nil, s.lock)()
+	return s.mat.Dims()
+}
+
+// At returns the value at row index i and column index j.
+func (s *SyncMatrix) At(i, j int) int {
+	defer lockSync(nil, s.lock)()
+	return s.mat.At(i, j)
+}
+
+// Set sets the value at row index i and column index j to value.
+func (s *SyncMatrix) Set(i, j int, value int) {
+	defer lockSync(s.lock)()
+	s.mat.Set(i, j, value)
+}
+
+// SetRow sets the values in row i to the values of row, a 1 x cols matrix such as a Row view.
+func (s *SyncMatrix) SetRow(i int, row *SyncMatrix) {
+	defer lockSync(s.lock, row.lock)()
+	if row.mat.rows != 1 {
+		panic(fmt.Sprintf("row must have a single row, got %vx%v", row.mat.rows, row.mat.cols))
+	}
+	// the row may be a view of this matrix
+	s.mat.SetRow(i, &Vector{mat: Copy(row.mat)})
+}
+
+// SetColumn sets the values in column j to the values of column, a rows x 1 matrix such as a Column
+// view.
+func (s *SyncMatrix) SetColumn(j int, column *SyncMatrix) {
+	defer lockSync(s.lock, column.lock)()
+	if column.mat.cols != 1 {
+		panic(fmt.Sprintf("column must have a single column, got %vx%v", column.mat.rows, column.mat.cols))
+	}
+	// the column may be a view of this matrix
+	s.mat.SetColumn(j, &TransposedVector{mat: Copy(column.mat)})
+}
+
+// Zeroize sets all the values of the matrix to zero.
+func (s *SyncMatrix) Zeroize() {
+	defer lockSync(s.lock)()
+	s.mat.Zeroize()
+}
+
+// ZeroizeRange sets the values in the given range to zero.
+func (s *SyncMatrix) ZeroizeRange(i, j, rows, cols int) {
+	defer lockSync(s.lock)()
+	s.mat.ZeroizeRange(i, j, rows, cols)
+}
+
+// SetMatrix replaces the values of this matrix with the values of a starting at (iOffset,jOffset).
+func (s *SyncMatrix) SetMatrix(a *SyncMatrix, iOffset, jOffset int) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.SetMatrix(a.mat, iOffset, jOffset)
+}
+
+// Negate negates every value in the matrix.
+func (s *SyncMatrix) Negate() {
+	defer lockSync(s.lock)()
+	s.mat.Negate()
+}
+
+// SwapRows swaps rows i and k.
+func (s *SyncMatrix) SwapRows(i, k int) {
+	defer lockSync(s.lock)()
+	s.mat.SwapRows(i, k)
+}
+
+// SwapCols swaps columns j and k.
+func (s *SyncMatrix) SwapCols(j, k int) {
+	defer lockSync(s.lock)()
+	s.mat.SwapCols(j, k)
+}
+
+// PermuteRows moves row i to row perm[i].
+func (s *SyncMatrix) PermuteRows(perm []int) {
+	defer lockSync(s.lock)()
+	s.mat.PermuteRows(perm)
+}
+
+// PermuteCols moves column j to column perm[j].
+func (s *SyncMatrix) PermuteCols(perm []int) {
+	defer lockSync(s.lock)()
+	s.mat.PermuteCols(perm)
+}
+
+// RemoveRows returns a new matrix without the given rows.
+func (s *SyncMatrix) RemoveRows(rows ...int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.RemoveRows(rows...))
+}
+
+// RemoveCols returns a new matrix without the given columns.
+func (s *SyncMatrix) RemoveCols(cols ...int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.RemoveCols(cols...))
+}
+
+// InsertZeroRows returns a new matrix with count zero rows inserted before row i.
+func (s *SyncMatrix) InsertZeroRows(i, count int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.InsertZeroRows(i, count))
+}
+
+// InsertZeroCols returns a new matrix with count zero columns inserted before column j.
+func (s *SyncMatrix) InsertZeroCols(j, count int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.InsertZeroCols(j, count))
+}
+
+// Pow returns the matrix raised to the power of k as a new matrix.
+func (s *SyncMatrix) Pow(k int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.Pow(k))
+}
+
+// PowBig returns the matrix raised to the power of k as a new matrix.
+func (s *SyncMatrix) PowBig(k *big.Int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.PowBig(k))
+}
+
+// PowMod returns the matrix raised to the power of k modulo m as a new matrix.
+func (s *SyncMatrix) PowMod(k *big.Int, m int) *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.PowMod(k, m))
+}
+
+// Mul stores the product of a and b in this matrix.
+func (s *SyncMatrix) Mul(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Mul(a.mat, b.mat)
+}
+
+// MulParallel stores the product of a and b in this matrix using workers goroutines.
+func (s *SyncMatrix) MulParallel(a, b *SyncMatrix, workers int) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.MulParallel(a.mat, b.mat, workers)
+}
+
+// Add stores the addition of a and b in this matrix.
+func (s *SyncMatrix) Add(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Add(a.mat, b.mat)
+}
+
+// Sub stores the subtraction a-b in this matrix.
+func (s *SyncMatrix) Sub(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Sub(a.mat, b.mat)
+}
+
+// AddScaled stores a+k*b in this matrix.
+func (s *SyncMatrix) AddScaled(a *SyncMatrix, k int, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.AddScaled(a.mat, k, b.mat)
+}
+
+// Scale stores a with every value multiplied by k in this matrix.
+func (s *SyncMatrix) Scale(a *SyncMatrix, k int) {
+	defer lockSync(s.lock, a.lock)()
+	s.mat.Scale(a.mat, k)
+}
+
+// BoolMul stores the Boolean product of a and b in this matrix.
+func (s *SyncMatrix) BoolMul(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.BoolMul(a.mat, b.mat)
+}
+
+// And stores the element wise logical and of a and b in this matrix.
+func (s *SyncMatrix) And(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.And(a.mat, b.mat)
+}
+
+// Or stores the element wise logical or of a and b in this matrix.
+func (s *SyncMatrix) Or(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.Or(a.mat, b.mat)
+}
+
+// XOr stores the element wise logical xor of a and b in this matrix.
+func (s *SyncMatrix) XOr(a, b *SyncMatrix) {
+	defer lockSync(s.lock, a.lock, b.lock)()
+	s.mat.XOr(a.mat, b.mat)
+}
+
+// TransitiveClosure returns the transitive closure as a new matrix.
+func (s *SyncMatrix) TransitiveClosure() *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.TransitiveClosure())
+}
+
+// ReflexiveTransitiveClosure returns the reflexive transitive closure as a new matrix.
+func (s *SyncMatrix) ReflexiveTransitiveClosure() *SyncMatrix {
+	defer lockSync(nil, s.lock)()
+	return freshSyncMatrix(s.mat.ReflexiveTransitiveClosure())
+}
+
+// OrderGF2 returns the multiplicative order of the matrix over GF(2).
+func (s *SyncMatrix) OrderGF2() *big.Int {
+	defer lockSync(nil, s.lock)()
+	return s.mat.OrderGF2()
+}
+
+// BFS returns the vertices reachable from start in breadth first order.
+func (s *SyncMatrix) BFS(start int) []int {
+	defer lockSync(nil, s.lock)()
+	return s.mat.BFS(start)
+}
+
+// DFS returns the vertices reachable from start in depth first order.
+func (s *SyncMatrix) DFS(start int) []int {
+	defer lockSync(nil, s.lock)()
+	return s.mat.DFS(start)
+}
+
+// ConnectedComponents returns the connected components of the graph with edge directions ignored.
+func (s *SyncMatrix) ConnectedComponents() [][]int {
+	defer lockSync(nil, s.lock)()
+	return s.mat.ConnectedComponents()
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph.
+func (s *SyncMatrix) StronglyConnectedComponents() [][]int {
+	defer lockSync(nil, s.lock)()
+	return s.mat.StronglyConnectedComponents()
+}
+
+// TopologicalSort returns a topological order of the graph or false if it has a cycle.
+func (s *SyncMatrix) TopologicalSort() ([]int, bool) {
+	defer lockSync(nil, s.lock)()
+	return s.mat.TopologicalSort()
+}
+
+// IsBipartite reports whether the graph can be 2-colored and the coloring when it can.
+func (s *SyncMatrix) IsBipartite() ([]int, bool) {
+	defer lockSync(nil, s.lock)()
+	return s.mat.IsBipartite()
+}
+
+// Equals return true if m has the same shape and values as this matrix.
+func (s *SyncMatrix) Equals(m *SyncMatrix) bool {
+	defer lockSync(nil, s.lock, m.lock)()
+	return s.mat.Equals(m.mat)
+}
+
+// DoNonzero calls fn for every nonzero value, see Matrix.DoNonzero. The read lock is held during the
+// calls so fn must not write to the matrix or any view of it.
+func (s *SyncMatrix) DoNonzero(fn func(i, j int, value int)) {
+	defer lockSync(nil, s.lock)()
+	s.mat.DoNonzero(fn)
+}
+
+func (s *SyncMatrix) String() string {
+	defer lockSync(nil, s.lock)()
+	return s.mat.String()
+}
@@ -0,0 +1,135 @@
+package intmat
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncMatrix_ConcurrentViews(t *testing.T) {
+	m := NewSyncMat(8, 8)
+	views := []*SyncMatrix{m, m.T(), m.Slice(2, 2, 4, 4), m.Row(3), m.Column(5)}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < 200; k++ {
+				m.Set((w+k)%8, k%8, k)
+			}
+		}(w)
+		go func(w int) {
+			defer wg.Done()
+			for k := 0; k < 200; k++ {
+				v := views[(w+k)%len(views)]
+				rows, cols := v.Dims()
+				v.At(k%rows, k%cols)
+				v.DoNonzero(func(i, j, value int) {})
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	m.Set(3, 5, 42)
+	if views[1].At(5, 3) != 42 || views[3].At(0, 5) != 42 || views[4].At(3, 0) != 42 || views[2].At(1, 3) != 42 {
+		t.Fatalf("views do not share storage")
+	}
+}
+
+func TestSyncMatrix_SharedLockOperands(t *testing.T) {
+	m := NewSyncMat(4, 4, 1, 2, 0, 0, 0, 1, 3, 0, 0, 0, 1, 4, 5, 0, 0, 1)
+	plain := m.Unwrap()
+
+	// a and b share the lock of m, the product must not deadlock
+	a, b := m.Slice(0, 0, 2, 4), m.Slice(0, 0, 4, 2)
+	actual := NewSyncMat(2, 2)
+	actual.Mul(a, b)
+
+	expected := NewMat(2, 2)
+	expected.Mul(plain.Slice(0, 0, 2, 4), plain.Slice(0, 0, 4, 2))
+	if !actual.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, actual)
+	}
+
+	// writing into a view while reading other views of the same storage
+	m.Slice(2, 2, 2, 2).Add(m.Slice(0, 0, 2, 2), m.Slice(0, 2, 2, 2))
+	plain.Slice(2, 2, 2, 2).Add(plain.Slice(0, 0, 2, 2), plain.Slice(0, 2, 2, 2))
+	if !m.Equals(NewSyncMatFrom(plain)) || !m.Equals(m) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", plain, m)
+	}
+}
+
+func TestSyncMatrix_SetRowColumn(t *testing.T) {
+	m := NewSyncMat(3, 3, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+	other := NewSyncMat(2, 3, 0, 0, 0, 10, 11, 12)
+
+	m.SetRow(0, other.Row(1))
+	m.SetRow(2, m.Row(1)) // a row of the same matrix
+	m.SetColumn(1, m.T().Row(0).T())
+	expected := NewMat(3, 3, 10, 10, 12, 4, 4, 6, 4, 4, 6)
+	if !m.Unwrap().Equals(expected) {
+		t.Fatalf("expected:\n%v\nbut found:\n%v", expected, m)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a multi row matrix to panic")
+		}
+	}()
+	m.SetRow(0, other)
+}
+
+func TestSyncMatrix_DimsWhileUnmarshaling(t *testing.T) {
+	m := NewSyncMat(2, 2)
+	bs, err := NewSyncMat(3, 4).MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if err := m.UnmarshalJSON(bs); err != nil {
+			t.Error(err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		m.Dims()
+	}()
+	wg.Wait()
+
+	if rows, cols := m.Dims(); rows != 3 || cols != 4 {
+		t.Fatalf("expected (3,4) but found (%v,%v)", rows, cols)
+	}
+}
+
+func TestSyncMatrix_Snapshot(t *testing.T) {
+	m := NewSyncMat(2, 2, 1, 2, 3, 4)
+	snapshots := make([]*SyncMatrix, 4)
+
+	var wg sync.WaitGroup
+	for k := range snapshots {
+		wg.Add(2)
+		go func(k int) {
+			defer wg.Done()
+			snapshots[k] = m.Snapshot()
+			snapshots[k].Set(0, 0, 10+k)
+		}(k)
+		go func(k int) {
+			defer wg.Done()
+			m.Set(1, 1, k)
+		}(k)
+	}
+	wg.Wait()
+
+	for k, snapshot := range snapshots {
+		if snapshot.At(0, 0) != 10+k || snapshot.At(0, 1) != 2 || snapshot.At(1, 0) != 3 {
+			t.Fatalf("unexpected snapshot:\n%v", snapshot)
+		}
+	}
+	if m.At(0, 0) != 1 {
+		t.Fatalf("snapshot writes must not reach the matrix:\n%v", m)
+	}
+}
@@ -90,7 +90,7 @@ func (vec *Vector) At(i int) int {
 }
 
 func (vec *Vector) at(j int) int {
-	return vec.mat.rowValues[vec.mat.rowStart][j]
+	return vec.mat.rowValues()[vec.mat.rowStart][j]
 }
 
 // Set sets the value at row index i to value.
@@ -120,14 +120,28 @@ func (vec *Vector) Dot(a *Vector) int {
 	return m.at(0, 0)
 }
 
+// NonzeroValues returns a map containing the non zero indices as the keys and it's associated values.
+// The keys are indices into this vector, so for a Slice they start at 0 like At and Set.
 func (vec *Vector) NonzeroValues() (indexToValues map[int]int) {
 	indexToValues = make(map[int]int)
 	end := vec.mat.colStart + vec.mat.cols
-	for c, v := range vec.mat.rowValues[vec.mat.rowStart] {
+	for c, v := range vec.mat.rowValues()[vec.mat.rowStart] {
 		if c < vec.mat.colStart || end <= c {
 			continue
 		}
-		indexToValues[c] = v
+		indexToValues[c-vec.mat.colStart] = v
+	}
+	return
+}
+
+// SortedNonzeroValues returns the non zero indices in increasing order along with their associated values.
+func (vec *Vector) SortedNonzeroValues() (indices []int, values []int) {
+	cs := vec.mat.rowValues()[vec.mat.rowStart]
+	indices = sortedInnerKeys(cs, vec.mat.colStart, vec.mat.cols)
+	values = make([]int, len(indices))
+	for k, c := range indices {
+		values[k] = cs[c]
+		indices[k] = c - vec.mat.colStart
 	}
 	return
 }
@@ -172,6 +186,48 @@ func (vec *Vector) Add(a, b *Vector) {
 	vec.mat.add(a.mat, b.mat)
 }
 
+// Sub sets vec equal to the difference a-b.
+func (vec *Vector) Sub(a, b *Vector) {
+	vec.checkLinear("subtraction", a, b)
+
+	vec.mat.addScaled(a.mat, -1, b.mat)
+}
+
+// AddScaled sets vec equal to a+s*b.
+func (vec *Vector) AddScaled(a *Vector, s int, b *Vector) {
+	vec.checkLinear("scaled addition", a, b)
+
+	vec.mat.addScaled(a.mat, s, b.mat)
+}
+
+// Scale sets vec equal to a with every value multiplied by s, a may be vec.
+func (vec *Vector) Scale(a *Vector, s int) {
+	if a == nil {
+		panic("scale input was found to be nil")
+	}
+	if vec.Len() != a.Len() {
+		panic("scaling vectors, destination must have the same length")
+	}
+
+	vec.mat.scale(a.mat, s)
+}
+
+func (vec *Vector) checkLinear(op string, a, b *Vector) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+	if vec == a || vec == b {
+		panic(fmt.Sprintf("%v self assignment not allowed", op))
+	}
+
+	if a.Len() != b.Len() {
+		panic(fmt.Sprintf("%v vectors must have the same length", op))
+	}
+	if vec.Len() != a.Len() {
+		panic(fmt.Sprintf("%v vectors, destination must have the same length", op))
+	}
+}
+
 func (vec *Vector) Equals(v *Vector) bool {
 	if vec == v {
 		return true
@@ -337,8 +393,8 @@ func (tvec *TransposedVector) MulVec(a *Matrix, b *TransposedVector) {
 		panic(fmt.Sprintf("multiply shape misalignment can't matrix-vector multiply (%v,%v)x(%v,1)", a.rows, a.cols, b.mat.rows))
 	}
 
-	if tvec.Len() != b.Len() {
-		panic(fmt.Sprintf("transposed vector length (%v) does not match expected (%v)", tvec.Len(), b.Len()))
+	if tvec.Len() != a.rows {
+		panic(fmt.Sprintf("transposed vector length (%v) does not match expected (%v)", tvec.Len(), a.rows))
 	}
 
 	tvec.mat.mul(a, b.mat)
@@ -362,6 +418,48 @@ func (tvec *TransposedVector) Add(a, b *TransposedVector) {
 	tvec.mat.add(a.mat, b.mat)
 }
 
+// Sub sets tvec equal to the difference a-b.
+func (tvec *TransposedVector) Sub(a, b *TransposedVector) {
+	tvec.checkLinear("subtraction", a, b)
+
+	tvec.mat.addScaled(a.mat, -1, b.mat)
+}
+
+// AddScaled sets tvec equal to a+s*b.
+func (tvec *TransposedVector) AddScaled(a *TransposedVector, s int, b *TransposedVector) {
+	tvec.checkLinear("scaled addition", a, b)
+
+	tvec.mat.addScaled(a.mat, s, b.mat)
+}
+
+// Scale sets tvec equal to a with every value multiplied by s, a may be tvec.
+func (tvec *TransposedVector) Scale(a *TransposedVector, s int) {
+	if a == nil {
+		panic("scale input was found to be nil")
+	}
+	if tvec.Len() != a.Len() {
+		panic("scaling transposed vectors, destination must have the same length")
+	}
+
+	tvec.mat.scale(a.mat, s)
+}
+
+func (tvec *TransposedVector) checkLinear(op string, a, b *TransposedVector) {
+	if a == nil || b == nil {
+		panic(fmt.Sprintf("%v input was found to be nil", op))
+	}
+	if tvec == a || tvec == b {
+		panic(fmt.Sprintf("%v self assignment not allowed", op))
+	}
+
+	if a.Len() != b.Len() {
+		panic(fmt.Sprintf("%v transposed vectors must have the same length", op))
+	}
+	if tvec.Len() != a.Len() {
+		panic(fmt.Sprintf("%v transposed vectors, destination must have the same length", op))
+	}
+}
+
 // At returns the value at index i.
 func (tvec *TransposedVector) At(j int) int {
 	tvec.checkBounds(j)
@@ -370,7 +468,7 @@ func (tvec *TransposedVector) At(j int) int {
 }
 
 func (tvec *TransposedVector) at(i int) int {
-	return tvec.mat.rowValues[i][tvec.mat.colStart]
+	return tvec.mat.rowValues()[i][tvec.mat.colStart]
 }
 
 // Set sets the value at row index i and column index j to value.
@@ -409,14 +507,28 @@ func (tvec *TransposedVector) Equals(v *TransposedVector) bool {
 	return tvec.mat.Equals(v.mat)
 }
 
+// NonzeroValues returns a map containing the non zero indices as the keys and it's associated values.
+// The keys are indices into this vector, so for a Slice they start at 0 like At and Set.
 func (tvec *TransposedVector) NonzeroValues() (indexToValues map[int]int) {
 	indexToValues = make(map[int]int)
 	end := tvec.mat.rowStart + tvec.mat.rows
-	for r, v := range tvec.mat.colValues[tvec.mat.colStart] {
+	for r, v := range tvec.mat.colValues()[tvec.mat.colStart] {
 		if r < tvec.mat.rowStart || end <= r {
 			continue
 		}
-		indexToValues[r] = v
+		indexToValues[r-tvec.mat.rowStart] = v
+	}
+	return
+}
+
+// SortedNonzeroValues returns the non zero indices in increasing order along with their associated values.
+func (tvec *TransposedVector) SortedNonzeroValues() (indices []int, values []int) {
+	rs := tvec.mat.colValues()[tvec.mat.colStart]
+	indices = sortedInnerKeys(rs, tvec.mat.rowStart, tvec.mat.rows)
+	values = make([]int, len(indices))
+	for k, r := range indices {
+		values[k] = rs[r]
+		indices[k] = r - tvec.mat.rowStart
 	}
 	return
 }
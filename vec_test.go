@@ -141,6 +141,7 @@ func TestVector_NonzeroValues(t *testing.T) {
 	}{
 		{Identity(4).Row(2), map[int]int{2: 1}},
 		{NewMat(4, 6, 1, 1, 0, 1, 0, 0, 0, 1, 1, 0, 1, 0, 1, 0, 0, 0, 1, 1, 0, 0, 1, 1, 0, 1).Row(0), map[int]int{0: 1, 1: 1, 3: 1}},
+		{NewVec(6, 1, 1, 0, 1, 0, 0).Slice(1, 3), map[int]int{0: 1, 2: 1}},
 	}
 	for i, test := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -152,6 +153,71 @@ func TestVector_NonzeroValues(t *testing.T) {
 	}
 }
 
+func TestVector_NonzeroValues_Keys(t *testing.T) {
+	// the keys of a view are the indices used by At, not the indices into the underlying storage
+	m := NewMat(3, 5, 1, 0, 2, 0, 3, 0, 4, 0, 5, 0, 6, 0, 7, 0, 8).Slice(1, 1, 2, 4)
+	rows := []struct {
+		input    *Vector
+		expected map[int]int
+	}{
+		{m.Row(0), map[int]int{0: 4, 2: 5}},
+		{m.Row(1), map[int]int{1: 7, 3: 8}},
+	}
+	for i, test := range rows {
+		actual := test.input.NonzeroValues()
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Fatalf("row %v: expected %v but found %v", i, test.expected, actual)
+		}
+		for k, v := range actual {
+			if test.input.At(k) != v {
+				t.Fatalf("row %v: expected At(%v) to be %v but found %v", i, k, v, test.input.At(k))
+			}
+		}
+	}
+
+	columns := []struct {
+		input    *TransposedVector
+		expected map[int]int
+	}{
+		{m.Column(0), map[int]int{0: 4}},
+		{m.Column(3), map[int]int{1: 8}},
+	}
+	for i, test := range columns {
+		actual := test.input.NonzeroValues()
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Fatalf("column %v: expected %v but found %v", i, test.expected, actual)
+		}
+		for k, v := range actual {
+			if test.input.At(k) != v {
+				t.Fatalf("column %v: expected At(%v) to be %v but found %v", i, k, v, test.input.At(k))
+			}
+		}
+	}
+}
+
+func TestVector_SortedNonzeroValues(t *testing.T) {
+	tests := []struct {
+		input           *Vector
+		expectedIndices []int
+		expectedValues  []int
+	}{
+		{NewVec(4), []int{}, []int{}},
+		{NewVec(6, 0, 3, 0, 1, 0, 2), []int{1, 3, 5}, []int{3, 1, 2}},
+		{NewVec(6, 0, 3, 0, 1, 0, 2).Slice(2, 4), []int{1, 3}, []int{1, 2}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			indices, values := test.input.SortedNonzeroValues()
+			if !reflect.DeepEqual(indices, test.expectedIndices) {
+				t.Fatalf("expected %v but found %v", test.expectedIndices, indices)
+			}
+			if !reflect.DeepEqual(values, test.expectedValues) {
+				t.Fatalf("expected %v but found %v", test.expectedValues, values)
+			}
+		})
+	}
+}
+
 func TestVector_Slice(t *testing.T) {
 	tests := []struct {
 		original         *Vector
@@ -312,6 +378,7 @@ func TestTransposedVector_MulVec(t *testing.T) {
 		expected *Vector
 	}{
 		{Identity(3), NewVec(3, 0, 1, 0), NewTVec(3), NewVec(3, 0, 1, 0)},
+		{NewMat(2, 3, 1, 1, 0, 0, 1, 1), NewVec(3, 1, 1, 1), NewTVec(2), NewVec(2, 2, 2)},
 	}
 	for i, test := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -346,6 +413,7 @@ func TestTransposedVector_NonzeroValues(t *testing.T) {
 		expected map[int]int
 	}{
 		{Identity(4).Column(2), map[int]int{2: 1}},
+		{NewTVec(6, 1, 1, 0, 1, 0, 0).Slice(1, 3), map[int]int{0: 1, 2: 1}},
 	}
 	for i, test := range tests {
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -357,6 +425,29 @@ func TestTransposedVector_NonzeroValues(t *testing.T) {
 	}
 }
 
+func TestTransposedVector_SortedNonzeroValues(t *testing.T) {
+	tests := []struct {
+		input           *TransposedVector
+		expectedIndices []int
+		expectedValues  []int
+	}{
+		{NewTVec(4), []int{}, []int{}},
+		{NewTVec(6, 2, 0, 0, 1, 0, 4), []int{0, 3, 5}, []int{2, 1, 4}},
+		{NewTVec(6, 2, 0, 0, 1, 0, 4).Slice(3, 3), []int{0, 2}, []int{1, 4}},
+	}
+	for i, test := range tests {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			indices, values := test.input.SortedNonzeroValues()
+			if !reflect.DeepEqual(indices, test.expectedIndices) {
+				t.Fatalf("expected %v but found %v", test.expectedIndices, indices)
+			}
+			if !reflect.DeepEqual(values, test.expectedValues) {
+				t.Fatalf("expected %v but found %v", test.expectedValues, values)
+			}
+		})
+	}
+}
+
 func TestTransposedVector_Slice(t *testing.T) {
 	tests := []struct {
 		original         *TransposedVector
@@ -532,3 +623,49 @@ func TestTransposedVector_Negate(t *testing.T) {
 		})
 	}
 }
+
+func TestVector_Linear(t *testing.T) {
+	a := NewVec(4, 6, -7, 0, 3)
+	b := NewVec(4, 4, 2, 1, -2)
+	tests := []struct {
+		name     string
+		op       func(vec *Vector)
+		expected *Vector
+	}{
+		{"Sub", func(vec *Vector) { vec.Sub(a, b) }, NewVec(4, 2, -9, -1, 5)},
+		{"AddScaled", func(vec *Vector) { vec.AddScaled(a, 3, b) }, NewVec(4, 18, -1, 3, -3)},
+		{"Scale", func(vec *Vector) { vec.Scale(a, -2) }, NewVec(4, -12, 14, 0, -6)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := NewVec(4)
+			test.op(actual)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTransposedVector_Linear(t *testing.T) {
+	a := NewTVec(3, 5, -3, 0)
+	b := NewTVec(3, 2, 2, 9)
+	tests := []struct {
+		name     string
+		op       func(tvec *TransposedVector)
+		expected *TransposedVector
+	}{
+		{"Sub", func(tvec *TransposedVector) { tvec.Sub(a, b) }, NewTVec(3, 3, -5, -9)},
+		{"AddScaled", func(tvec *TransposedVector) { tvec.AddScaled(a, -1, b) }, NewTVec(3, 3, -5, -9)},
+		{"Scale", func(tvec *TransposedVector) { tvec.Scale(a, 4) }, NewTVec(3, 20, -12, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := NewTVec(3)
+			test.op(actual)
+			if !actual.Equals(test.expected) {
+				t.Fatalf("expected %v but found %v", test.expected, actual)
+			}
+		})
+	}
+}